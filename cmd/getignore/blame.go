@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Blame = &cli.Command{
+	Name:  "blame",
+	Usage: "shows where each line of a gitignore file came from, and the commit that last changed it",
+	Flags: append(commonFlags, []cli.Flag{
+		&cli.IntFlag{
+			Name:    "limit",
+			Aliases: []string{"L"},
+			Usage:   "The maximum number of commits to search for changes to each gitignore patterns file",
+			Value:   30,
+		},
+	}...),
+	ArgsUsage: "[path]",
+	Action:    blameFile,
+}
+
+func blameFile(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return errors.New("blame accepts at most one path")
+	}
+	path := ".gitignore"
+	if c.NArg() == 1 {
+		path = c.Args().First()
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	names := getignore.SectionNames(string(contents))
+	lockedFiles, err := lockedSectionFiles(path, names)
+	if err != nil {
+		return err
+	}
+	templates := make(map[int]getignore.TemplateBlame)
+	revisions := make(map[string]string)
+	for i, name := range names {
+		if name == getignore.InlineContentsName || lockedFiles[i].Source == getignore.LocalSource {
+			continue
+		}
+		template, err := blameTemplate(c, name, lockedFiles[i], revisions)
+		if err != nil {
+			log.Printf("Treating section %s as local: %v", name, err)
+			continue
+		}
+		templates[i] = template
+	}
+	return getignore.WriteBlame(os.Stdout, getignore.BlameFile(string(contents), templates))
+}
+
+// lockedSectionFiles returns the file recorded by the lock file of the
+// gitignore file, if it has one, for each of the sections with the names
+func lockedSectionFiles(path string, names []string) ([]getignore.LockedFile, error) {
+	lock, err := getignore.ReadLock(path + getignore.LockFileSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]getignore.LockedFile, len(names)), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return lock.SectionFiles(names), nil
+}
+
+// blameTemplate blames the lines of the gitignore patterns file of the
+// section, at the version recorded by the lock file if it is locked, or else
+// the latest version. It gets the file as of each commit at most once, using
+// the revisions got before, and only as far back as needed.
+func blameTemplate(c *cli.Context, section string, lf getignore.LockedFile, revisions map[string]string) (getignore.TemplateBlame, error) {
+	name := section
+	if lf.Path != "" {
+		name = lf.Path
+	}
+	getter, err := newGithubSourceGetter(c, lf.Source)
+	if err != nil {
+		return getignore.TemplateBlame{}, err
+	}
+	limit := c.Int("limit")
+	filePath, commits, err := getter.History(c.Context, name, limit)
+	if err != nil {
+		return getignore.TemplateBlame{}, err
+	}
+	if len(commits) == 0 {
+		return getignore.TemplateBlame{}, fmt.Errorf("no history found for %s", filePath)
+	}
+	getContents := func(commit getignore.Commit) (string, error) {
+		key := fmt.Sprintf("%s:%s@%s", lf.Source, filePath, commit.SHA)
+		if contents, ok := revisions[key]; ok {
+			return contents, nil
+		}
+		contents, err := getter.GetAt(c.Context, filePath, commit.SHA)
+		if err == nil {
+			revisions[key] = contents
+		}
+		return contents, err
+	}
+	// With as many commits as the limit, older ones may have been left out
+	complete := limit <= 0 || len(commits) < limit
+	if lf.SHA != "" {
+		if commits, err = commitsFrom(filePath, commits, lf.SHA, complete, getContents); err != nil {
+			return getignore.TemplateBlame{}, err
+		}
+	}
+	return getignore.BlameCommits(commits, complete, getContents)
+}
+
+// commitsFrom returns the commits starting from the newest one with which the
+// file has the contents of the blob. If none has those contents, it returns
+// all of them when complete, or else fails, as the locked version is older
+// than the commits searched.
+func commitsFrom(filePath string, commits []getignore.Commit, sha string, complete bool, getContents getignore.RevisionGetter) ([]getignore.Commit, error) {
+	for i, commit := range commits {
+		contents, err := getContents(commit)
+		if err != nil {
+			return nil, err
+		}
+		if getignore.BlobSHA(contents) == sha {
+			return commits[i:], nil
+		}
+	}
+	if !complete {
+		return nil, fmt.Errorf("the locked version of %s is older than the %d commits searched; use a larger --limit", filePath, len(commits))
+	}
+	return commits, nil
+}
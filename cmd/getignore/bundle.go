@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Bundle = &cli.Command{
+	Name:  "bundle",
+	Usage: "manages bundles, snapshots of sources for use without the network",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "snapshots every gitignore patterns file of a source, with checksums, into a bundle",
+			Flags: append(commonFlags, []cli.Flag{
+				maxRequestsFlag,
+				&cli.StringFlag{
+					Name:     "output-file",
+					Aliases:  []string{"o"},
+					Usage:    "Path to the bundle file to create, e.g., gitignore.tar.gz",
+					Required: true,
+				},
+			}...),
+			ArgsUsage: "[source]",
+			Action:    createBundle,
+		},
+	},
+}
+
+func createBundle(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return errors.New("bundle create accepts at most one source")
+	}
+	source := c.Args().First()
+	getter, err := newSourceGetter(c, source, nil)
+	if err != nil {
+		return err
+	}
+	sourceName := source
+	if source == "" {
+		sourceName = defaultSource(c)
+	} else if isSourceKind(source) {
+		sourceName = kindSource(c, source)
+	}
+	b, err := bundle.Create(c.Context, getter, sourceName, time.Now())
+	if err != nil {
+		return err
+	}
+	path := c.String("output-file")
+	log.Printf("Writing %d files from %s to %s", len(b.Manifest.Files), sourceName, path)
+	return getignore.WriteFileAtomic(path, func(w io.Writer) error {
+		return b.Write(w)
+	}, false)
+}
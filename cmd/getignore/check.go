@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Check = &cli.Command{
+	Name:  "check",
+	Usage: "checks that the section of a gitignore file managed by getignore matches its lock file",
+	Flags: append(commonFlags, []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"F"},
+			Usage:   "Format of problems found: text, json, or github",
+			Value:   getignore.FindingsFormatText,
+		},
+	}...),
+	ArgsUsage: "[path]",
+	Action:    checkFile,
+}
+
+func checkFile(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return errors.New("check accepts at most one path")
+	}
+	path := ".gitignore"
+	if c.NArg() == 1 {
+		path = c.Args().First()
+	}
+	findings, err := checkManagedFile(c, path)
+	if err != nil {
+		return err
+	}
+	if err := getignore.WriteFindings(os.Stdout, findings, c.String("format")); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%s does not match its lock file", path)
+	}
+	log.Println(path, "matches its lock file")
+	return nil
+}
+
+func checkManagedFile(c *cli.Context, path string) ([]getignore.Finding, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mf, ok := getignore.ParseManagedFile(string(contents))
+	if !ok {
+		return []getignore.Finding{{File: path, Line: 1, Message: "no section managed by getignore found"}}, nil
+	}
+	lock, err := getignore.ReadLock(path + getignore.LockFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	namesList, err := bannerNamesList(mf.Banner)
+	if err != nil {
+		return nil, err
+	}
+	findings := getignore.CheckNames(path, mf, namesList, lock)
+	expected, err := getLockedContents(c, lock)
+	if err != nil {
+		return nil, err
+	}
+	var changedLocalFiles []string
+	for i, lf := range lock.Files {
+		if lf.Source != getignore.LocalSource {
+			continue
+		}
+		if localFindings := getignore.CheckLocalFile(path, mf, lf, expected[i]); len(localFindings) > 0 {
+			findings = append(findings, localFindings...)
+			changedLocalFiles = append(changedLocalFiles, expected[i].Name)
+		}
+	}
+	expected = append(expected, getignore.InlineContents(namesList.Names)...)
+	if len(mf.Banner.Add) > 0 {
+		expected = append(expected, getignore.NewInlineContents(mf.Banner.Add))
+	}
+	return append(findings, getignore.CheckSections(path, mf, expected, changedLocalFiles)...), nil
+}
+
+// getLockedContents gets the contents of the files at the versions recorded
+// by the lock, or the current contents of local files
+func getLockedContents(c *cli.Context, lock getignore.Lock) ([]getignore.NamedContents, error) {
+	var contents []getignore.NamedContents
+	getters := make(map[string]blobSource)
+	for _, lf := range lock.Files {
+		if lf.Source == getignore.LocalSource {
+			nc, err := getignore.ReadLocalFile(lf.Path)
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, nc)
+			continue
+		}
+		getter, ok := getters[lf.Source]
+		if !ok {
+			var err error
+			getter, err = newBlobSourceGetter(c, lf.Source)
+			if err != nil {
+				return nil, err
+			}
+			getters[lf.Source] = getter
+		}
+		blobContents, err := getter.GetBlob(c.Context, lf.SHA)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, getignore.NamedContents{Name: lf.Path, Contents: blobContents, Source: lf.Source})
+	}
+	return contents, nil
+}
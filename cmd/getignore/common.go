@@ -1,15 +1,64 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/bitbucket"
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitea"
 	"github.com/gotgenes/getignore/pkg/github"
+	"github.com/gotgenes/getignore/pkg/gitignoreio"
+	"github.com/gotgenes/getignore/pkg/gitlab"
+	"github.com/gotgenes/getignore/pkg/gitremote"
+	"github.com/gotgenes/getignore/pkg/httpindex"
 	"github.com/urfave/cli/v2"
 )
 
+// Kinds of hosts of gitignore repositories
+const (
+	githubSource              = "github"
+	gitlabSource              = "gitlab"
+	giteaSource               = "gitea"
+	bitbucketSource           = "bitbucket"
+	bitbucketDataCenterSource = "bitbucket-datacenter"
+	gitSource                 = "git"
+	gitignoreioSource         = "gitignoreio"
+	indexSource               = "index"
+	bundleSource              = "bundle"
+	embeddedSource            = "embedded"
+)
+
+var sourceKinds = []string{githubSource, gitlabSource, giteaSource, bitbucketSource, bitbucketDataCenterSource, gitSource, gitignoreioSource, indexSource, bundleSource, embeddedSource}
+
+// repositoryKinds are the kinds of hosts with repositories given as
+// owner/repository
+var repositoryKinds = []string{githubSource, gitlabSource, giteaSource, bitbucketSource, bitbucketDataCenterSource}
+
+// Layouts of gitignore repositories
+const (
+	gitignoreLayout = "gitignore"
+	toptalLayout    = "toptal"
+)
+
 var commonFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "source",
+		Aliases: []string{"S"},
+		Usage:   "Kind of host of the gitignore repository: " + strings.Join(sourceKinds, ", "),
+		Value:   githubSource,
+	},
 	&cli.StringFlag{
 		Name:    "base-url",
 		Aliases: []string{"u"},
-		Usage:   "The base URL for the GitHub REST API v3 compatible server",
+		Usage:   "The base URL for the GitHub REST API v3 compatible server, Bitbucket Cloud API, or gitignore.io compatible API, or of the GitLab, Gitea, or Bitbucket Data Center instance",
 	},
 	&cli.StringFlag{
 		Name:    "owner",
@@ -26,8 +75,34 @@ var commonFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:    "branch",
 		Aliases: []string{"b"},
-		Usage:   "Branch or commit to inspect for the gitignore repository",
-		Value:   github.Branch,
+		Usage:   "Branch or commit to inspect for the gitignore repository (default: \"" + github.Branch + "\" on GitHub, or the default branch of the repository elsewhere)",
+	},
+	&cli.StringFlag{
+		Name:    "project",
+		Aliases: []string{"p"},
+		Usage:   "Path or ID of the GitLab project of the gitignore repository, required for GitLab unless --owner and --repository are given",
+	},
+	&cli.StringFlag{
+		Name:  "remote",
+		Usage: "URL of the git remote of the gitignore repository, for the " + gitSource + " source, e.g., https://example.com/templates.git",
+	},
+	&cli.StringFlag{
+		Name:  "index-url",
+		Usage: "URL of the index.json listing the gitignore patterns files, for the " + indexSource + " source, e.g., https://example.com/gitignore/index.json",
+	},
+	&cli.StringFlag{
+		Name:  "bundle",
+		Usage: "Path of the bundle file, for the " + bundleSource + " source, as created by bundle create",
+	},
+	&cli.StringFlag{
+		Name:    "token",
+		Usage:   "Access token, or app password, for the gitignore repository on hosts other than GitHub, or for the host of the index given by --index-url",
+		EnvVars: []string{"GETIGNORE_TOKEN"},
+	},
+	&cli.StringFlag{
+		Name:    "username",
+		Usage:   "User to authenticate to Bitbucket as, with the token as the password",
+		EnvVars: []string{"GETIGNORE_USERNAME"},
 	},
 	&cli.StringFlag{
 		Name:    "suffix",
@@ -35,6 +110,124 @@ var commonFlags = []cli.Flag{
 		Usage:   "The suffix to use to identify ignore files",
 		Value:   github.Suffix,
 	},
+	&cli.StringFlag{
+		Name:  "layout",
+		Usage: "Layout of the gitignore repository: " + gitignoreLayout + ", or " + toptalLayout + ", in which patches follow templates and stacks bundle them",
+		Value: gitignoreLayout,
+	},
+}
+
+var maxRequestsFlag = &cli.IntFlag{
+	Name:    "max-requests",
+	Aliases: []string{"m"},
+	Usage:   "The number of maximum connections to open for HTTP requests",
+	Value:   github.DefaultMaxRequests,
+}
+
+var outputFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "backup",
+		Usage: "Keep the previous version of the output file, with '" + getignore.BackupSuffix + "' appended to its name",
+	},
+	&cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Report whether the output file would change, without writing it, failing if it would",
+	},
+	&cli.BoolFlag{
+		Name:  "diff",
+		Usage: "Show how the output file would change, without writing it, failing if it would",
+	},
+}
+
+// writeOrPreview atomically replaces the file with what write writes, or, in
+// a dry run, reports whether the file would change, showing the differences if
+// showDiff is true, and fails if it would
+func writeOrPreview(c *cli.Context, path string, showDiff bool, write func(io.Writer) error) error {
+	if !c.Bool("dry-run") && !c.Bool("diff") {
+		log.Println("Writing contents to", path)
+		return getignore.WriteFileAtomic(path, write, c.Bool("backup"))
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var updated strings.Builder
+	if err := write(&updated); err != nil {
+		return err
+	}
+	diff := getignore.UnifiedDiff(path, path, string(existing), updated.String())
+	if diff == "" {
+		log.Println(path, "is up to date")
+		return nil
+	}
+	if showDiff {
+		fmt.Print(diff)
+	}
+	return fmt.Errorf("%s would change", path)
+}
+
+var colorFlag = &cli.StringFlag{
+	Name:  "color",
+	Usage: "When to highlight output: auto, always, or never",
+	Value: "auto",
+}
+
+// useColor reports whether to highlight output to the file, according to the
+// color flag; with "auto", output is highlighted only on terminals
+func useColor(c *cli.Context, f *os.File) (bool, error) {
+	switch c.String("color") {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			return false, nil
+		}
+		info, err := f.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid value for --color: %s", c.String("color"))
+	}
+}
+
+// newFilterFlags returns the flags selecting files by path, for the command
+// given by the verb
+func newFilterFlags(verb string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "filter",
+			Aliases: []string{"f"},
+			Usage:   verb + " only files with paths matching a glob, or a regular expression prefixed with 're:'",
+		},
+		&cli.StringFlag{
+			Name:    "category",
+			Aliases: []string{"C"},
+			Usage:   verb + " only files in the top-level directory, e.g., Global or community",
+		},
+	}
+}
+
+// getFilteredContents gets the contents of the files passing the filter and
+// category flags, along with the files that failed, if others were got
+func getFilteredContents(c *cli.Context, getter getignore.Source) ([]getignore.NamedContents, getignore.FailedFiles, error) {
+	filters, err := getignore.ParsePatterns(c.StringSlice("filter"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return getignore.GetFilteredContents(c.Context, getter, filters, c.String("category"))
+}
+
+// reportFailedFiles logs the files that couldn't be got for the command, so
+// its results are known to be incomplete, failing if there are any
+func reportFailedFiles(failedFiles getignore.FailedFiles, command string) error {
+	if len(failedFiles) == 0 {
+		return nil
+	}
+	for _, failedFile := range failedFiles {
+		log.Println(failedFile)
+	}
+	return fmt.Errorf("unable to %s %d of the gitignore patterns files", command, len(failedFiles))
 }
 
 var stringFlagsToOptions = map[string]func(string) github.GetterOption{
@@ -42,22 +235,477 @@ var stringFlagsToOptions = map[string]func(string) github.GetterOption{
 	"owner":      github.WithOwner,
 	"repository": github.WithRepository,
 	"branch":     github.WithBranch,
-	"suffix":     github.WithSuffix,
 }
 
-func newGithubGetter(c *cli.Context) (github.Getter, error) {
-	var opts []github.GetterOption
+func loadConfig(c *cli.Context) (getignore.Config, error) {
+	return getignore.LoadConfig(c.String("config"), c.IsSet("config"))
+}
+
+// getterSettings holds the settings shared by getters for every kind of
+// source
+type getterSettings struct {
+	suffix      string
+	aliases     map[string]string
+	maxRequests int
+	fuzzy       bool
+	excludes    []getignore.Pattern
+	// layers are the sources to get files from, in order of precedence,
+	// when no other source is given
+	layers []string
+}
+
+// resolverOptions returns the options for getters to resolve names with the
+// settings, leaving out the files with the names
+func (s getterSettings) resolverOptions(excludeNames []string) []getignore.ResolverOption {
+	return []getignore.ResolverOption{
+		getignore.WithSuffix(s.suffix),
+		getignore.WithAliases(s.aliases),
+		getignore.WithFuzzy(s.fuzzy),
+		getignore.WithExcludes(s.excludes),
+		getignore.WithExcludeNames(excludeNames),
+	}
+}
+
+func loadGetterSettings(c *cli.Context) (getterSettings, error) {
+	config, err := loadConfig(c)
+	if err != nil {
+		return getterSettings{}, err
+	}
+	settings := getterSettings{
+		suffix:      c.String("suffix"),
+		aliases:     config.Aliases,
+		maxRequests: getignore.DefaultMaxRequests,
+		fuzzy:       c.Bool("fuzzy"),
+		layers:      config.Sources,
+	}
+	if c.IsSet("max-requests") {
+		settings.maxRequests = c.Int("max-requests")
+	}
+	if c.IsSet("exclude") {
+		settings.excludes, err = getignore.ParsePatterns(c.StringSlice("exclude"))
+		if err != nil {
+			return getterSettings{}, err
+		}
+	}
+	return settings, nil
+}
+
+// newSourceGetter returns the getter for a source given by
+// getignore.SplitSource, overriding the repository given by flags, and
+// leaving out the files with the names
+func newSourceGetter(c *cli.Context, source string, excludeNames []string) (getignore.Source, error) {
+	settings, err := loadGetterSettings(c)
+	if err != nil {
+		return nil, err
+	}
+	if source == getignore.LayeredSourceName || source == "" && usesLayers(c, settings) {
+		return newLayeredGetter(c, settings, excludeNames)
+	}
+	getter, err := newLayoutGetter(c, source, settings, excludeNames)
+	if err != nil || !isSourceKind(source) || kindSource(c, source) == source {
+		return getter, err
+	}
+	return attributedSource{Source: getter, source: kindSource(c, source)}, nil
+}
+
+// isSourceKind reports whether the source is a kind of host alone, e.g.,
+// github, standing for the repository given by flags
+func isSourceKind(source string) bool {
+	for _, kind := range sourceKinds {
+		if source == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// isRepositoryKind reports whether the kind of host has repositories given as
+// owner/repository
+func isRepositoryKind(kind string) bool {
+	for _, repositoryKind := range repositoryKinds {
+		if kind == repositoryKind {
+			return true
+		}
+	}
+	return false
+}
+
+// attributedSource attributes the files it gets to a source, e.g., the
+// repository a kind of host alone stands for, so it is recorded in lock files
+type attributedSource struct {
+	getignore.Source
+	source string
+}
+
+func (s attributedSource) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	contents, err := s.Source.Get(ctx, names)
+	for i := range contents {
+		contents[i].Source = s.source
+	}
+	return contents, err
+}
+
+// Unwrap returns the source the files are got from
+func (s attributedSource) Unwrap() getignore.Getter {
+	return s.Source
+}
+
+// sourceFlagNames are the flags that give a source, in place of any layers
+var sourceFlagNames = []string{"source", "base-url", "owner", "repository", "branch", "project", "remote", "index-url", "bundle"}
+
+// usesLayers reports whether files come from the layers in the settings,
+// rather than from a source given by flags
+func usesLayers(c *cli.Context, settings getterSettings) bool {
+	if len(settings.layers) == 0 {
+		return false
+	}
+	for _, flagName := range sourceFlagNames {
+		if c.IsSet(flagName) {
+			return false
+		}
+	}
+	return true
+}
+
+// newLayeredGetter returns the getter for the layers in the settings, each
+// with the layout given by flags
+func newLayeredGetter(c *cli.Context, settings getterSettings, excludeNames []string) (getignore.LayeredSource, error) {
+	if len(settings.layers) == 0 {
+		return getignore.LayeredSource{}, errors.New("no sources to layer are configured")
+	}
+	layers := make([]getignore.Layer, len(settings.layers))
+	for i, layerSource := range settings.layers {
+		// The layered source resolves names itself, from the files of every layer
+		getter, err := newLayoutGetter(c, layerSource, getterSettings{suffix: settings.suffix, maxRequests: settings.maxRequests}, nil)
+		if err != nil {
+			return getignore.LayeredSource{}, err
+		}
+		layers[i] = getignore.Layer{Name: layerSource, Source: getter}
+	}
+	return getignore.LayeredSource{
+		Layers: layers,
+		ResolverSettings: getignore.ResolverSettings{
+			Suffix:       settings.suffix,
+			Fuzzy:        settings.fuzzy,
+			Aliases:      settings.aliases,
+			Excludes:     settings.excludes,
+			ExcludeNames: excludeNames,
+		},
+	}, nil
+}
+
+// newLayoutGetter returns the getter for the repository given by the source,
+// if any, or else by flags, with the layout given by flags
+func newLayoutGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (getignore.Source, error) {
+	switch layout := c.String("layout"); layout {
+	case gitignoreLayout:
+		return newRepositoryGetter(c, source, settings, excludeNames)
+	case toptalLayout:
+		// The layout resolves names itself, from every file in the repository
+		getter, err := newRepositoryGetter(c, source, getterSettings{maxRequests: settings.maxRequests}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := getter.(gitignoreio.Getter); ok {
+			return nil, fmt.Errorf("the %s layout is only supported for repositories", layout)
+		}
+		return gitignoreio.Layout{
+			Source:       getter,
+			Fuzzy:        settings.fuzzy,
+			Aliases:      settings.aliases,
+			Excludes:     settings.excludes,
+			ExcludeNames: excludeNames,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown layout: %s", layout)
+	}
+}
+
+// newRepositoryGetter returns the getter for the repository given by the
+// source, if any, or else by flags
+func newRepositoryGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (getignore.Source, error) {
+	if gitremote.IsSource(source) {
+		return newGitGetter(c, source, settings, excludeNames)
+	}
+	if gitignoreio.IsSource(source) {
+		return newGitignoreioGetter(c, source, settings, excludeNames)
+	}
+	if httpindex.IsSource(source) {
+		return newIndexGetter(c, source, settings, excludeNames)
+	}
+	if bundle.IsSource(source) {
+		return newBundleGetter(c, source, settings, excludeNames)
+	}
+	var (
+		rs  getignore.RepositorySource
+		err error
+	)
+	if source != "" {
+		rs, err = getignore.ParseRepositorySource(source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	kind := rs.Kind
+	if kind == "" {
+		kind = c.String("source")
+		if rs.Repository != "" && !isRepositoryKind(kind) {
+			// Repositories without a kind are on GitHub when the source flag
+			// gives a kind of source without repositories, e.g., git
+			kind = githubSource
+		}
+	}
+	switch kind {
+	case githubSource:
+		return newGithubGetter(c, rs, settings, excludeNames)
+	case gitlabSource:
+		return newGitlabGetter(c, rs, settings, excludeNames)
+	case giteaSource:
+		return newGiteaGetter(c, rs, settings, excludeNames)
+	case bitbucketSource, bitbucketDataCenterSource:
+		return newBitbucketGetter(c, rs, settings, excludeNames, kind == bitbucketDataCenterSource)
+	case gitSource:
+		if rs.Repository != "" {
+			return nil, fmt.Errorf("invalid source %q: git sources are given as %s", source, gitremote.FormatSource("URL", "ref"))
+		}
+		return newGitGetter(c, "", settings, excludeNames)
+	case gitignoreioSource:
+		if rs.Repository != "" {
+			return nil, fmt.Errorf("invalid source %q: gitignore.io sources are given as %s", source, gitignoreio.FormatSource("URL"))
+		}
+		return newGitignoreioGetter(c, "", settings, excludeNames)
+	case indexSource:
+		if rs.Repository != "" {
+			return nil, fmt.Errorf("invalid source %q: index sources are given as %s", source, httpindex.FormatSource("URL"))
+		}
+		return newIndexGetter(c, "", settings, excludeNames)
+	case bundleSource:
+		if rs.Repository != "" {
+			return nil, fmt.Errorf("invalid source %q: bundle sources are given as %s", source, bundle.FormatSource("PATH"))
+		}
+		return newBundleGetter(c, "", settings, excludeNames)
+	case embeddedSource:
+		if rs.Repository != "" {
+			return nil, fmt.Errorf("invalid source %q: the embedded source takes no repository", source)
+		}
+		return newEmbeddedGetter(settings, excludeNames)
+	default:
+		return nil, fmt.Errorf("unknown kind of source: %s", kind)
+	}
+}
+
+// blobSource is a source that gets files by their git blob SHAs
+type blobSource interface {
+	getignore.Source
+	getignore.BlobGetter
+}
+
+// newBlobSourceGetter returns the getter for a source, for commands that
+// compare files with lock files by their git blob SHAs
+func newBlobSourceGetter(c *cli.Context, source string) (blobSource, error) {
+	getter, err := newSourceGetter(c, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	blobGetter, ok := getter.(blobSource)
+	if !ok {
+		return nil, fmt.Errorf("%s is only supported for sources that identify files by git blob SHA", c.Command.Name)
+	}
+	return blobGetter, nil
+}
+
+// newGithubSourceGetter returns the getter for a source, for commands that
+// only GitHub sources support
+func newGithubSourceGetter(c *cli.Context, source string) (github.Getter, error) {
+	getter, err := newSourceGetter(c, source, nil)
+	if err != nil {
+		return github.Getter{}, err
+	}
+	githubGetter, ok := getter.(github.Getter)
+	if !ok {
+		return github.Getter{}, fmt.Errorf("%s is only supported for GitHub sources", c.Command.Name)
+	}
+	return githubGetter, nil
+}
+
+func newGithubGetter(c *cli.Context, rs getignore.RepositorySource, settings getterSettings, excludeNames []string) (github.Getter, error) {
+	opts := []github.GetterOption{
+		github.WithResolver(settings.resolverOptions(excludeNames)...),
+		github.WithMaxRequests(settings.maxRequests),
+	}
 	for _, flagName := range c.FlagNames() {
-		if flagName == "max-requests" {
-			opts = append(opts, github.WithMaxRequests(c.Int(flagName)))
-		} else {
-			value := c.String(flagName)
-			optFunc, ok := stringFlagsToOptions[flagName]
-			if ok {
-				opts = append(opts, optFunc(value))
-			}
-		}
-	}
-	getter, err := github.NewGetter(opts...)
-	return getter, err
+		if optFunc, ok := stringFlagsToOptions[flagName]; ok {
+			opts = append(opts, optFunc(c.String(flagName)))
+		}
+	}
+	if rs.Repository != "" {
+		opts = append(opts, github.WithOwner(rs.Owner), github.WithRepository(rs.Repository))
+	}
+	if rs.Ref != "" {
+		opts = append(opts, github.WithBranch(rs.Ref))
+	}
+	return github.NewGetter(opts...)
+}
+
+func newGitlabGetter(c *cli.Context, rs getignore.RepositorySource, settings getterSettings, excludeNames []string) (gitlab.Getter, error) {
+	opts := []gitlab.GetterOption{
+		gitlab.WithRef(refFlag(c)),
+		gitlab.WithToken(c.String("token")),
+		gitlab.WithResolver(settings.resolverOptions(excludeNames)...),
+		gitlab.WithMaxRequests(settings.maxRequests),
+	}
+	if c.String("base-url") != "" {
+		opts = append(opts, gitlab.WithBaseURL(c.String("base-url")))
+	}
+	if rs.Repository != "" {
+		opts = append(opts, gitlab.WithProject(rs.Path()))
+	} else {
+		project, err := gitlabProject(c)
+		if err != nil {
+			return gitlab.Getter{}, err
+		}
+		opts = append(opts, gitlab.WithProject(project))
+	}
+	if rs.Ref != "" {
+		opts = append(opts, gitlab.WithRef(rs.Ref))
+	}
+	return gitlab.NewGetter(opts...)
+}
+
+// gitlabProject returns the GitLab project given by the project flag, or else
+// by the owner and repository flags, which have no defaults for GitLab
+func gitlabProject(c *cli.Context) (string, error) {
+	if c.String("project") != "" {
+		return c.String("project"), nil
+	}
+	if !c.IsSet("owner") || !c.IsSet("repository") {
+		return "", fmt.Errorf("--project, or --owner and --repository, is required for the %s source", gitlabSource)
+	}
+	return c.String("owner") + "/" + c.String("repository"), nil
+}
+
+func newGiteaGetter(c *cli.Context, rs getignore.RepositorySource, settings getterSettings, excludeNames []string) (gitea.Getter, error) {
+	opts := []gitea.GetterOption{
+		gitea.WithBaseURL(c.String("base-url")),
+		gitea.WithOwner(c.String("owner")),
+		gitea.WithRepository(c.String("repository")),
+		gitea.WithRef(refFlag(c)),
+		gitea.WithToken(c.String("token")),
+		gitea.WithResolver(settings.resolverOptions(excludeNames)...),
+		gitea.WithMaxRequests(settings.maxRequests),
+	}
+	if rs.Repository != "" {
+		opts = append(opts, gitea.WithOwner(rs.Owner), gitea.WithRepository(rs.Repository))
+	}
+	if rs.Ref != "" {
+		opts = append(opts, gitea.WithRef(rs.Ref))
+	}
+	return gitea.NewGetter(opts...)
+}
+
+func newBitbucketGetter(c *cli.Context, rs getignore.RepositorySource, settings getterSettings, excludeNames []string, dataCenter bool) (bitbucket.Getter, error) {
+	opts := []bitbucket.GetterOption{
+		bitbucket.WithBaseURL(c.String("base-url")),
+		bitbucket.WithDataCenter(dataCenter),
+		bitbucket.WithOwner(c.String("owner")),
+		bitbucket.WithRepository(c.String("repository")),
+		bitbucket.WithRef(refFlag(c)),
+		bitbucket.WithUsername(c.String("username")),
+		bitbucket.WithToken(c.String("token")),
+		bitbucket.WithResolver(settings.resolverOptions(excludeNames)...),
+		bitbucket.WithMaxRequests(settings.maxRequests),
+	}
+	if rs.Repository != "" {
+		opts = append(opts, bitbucket.WithOwner(rs.Owner), bitbucket.WithRepository(rs.Repository))
+	}
+	if rs.Ref != "" {
+		opts = append(opts, bitbucket.WithRef(rs.Ref))
+	}
+	return bitbucket.NewGetter(opts...)
+}
+
+// newGitGetter returns the getter for the git remote given by the source, if
+// any, or else by the remote and branch flags
+func newGitGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (gitremote.Getter, error) {
+	opts := []gitremote.GetterOption{
+		gitremote.WithURL(c.String("remote")),
+		gitremote.WithRef(refFlag(c)),
+		gitremote.WithResolver(settings.resolverOptions(excludeNames)...),
+	}
+	if source != "" {
+		url, ref, err := gitremote.ParseSource(source)
+		if err != nil {
+			return gitremote.Getter{}, err
+		}
+		opts = append(opts, gitremote.WithURL(url), gitremote.WithRef(ref))
+	}
+	return gitremote.NewGetter(opts...)
+}
+
+// newGitignoreioGetter returns the getter for the gitignore.io compatible API
+// given by the source, if any, or else by the base URL flag
+func newGitignoreioGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (gitignoreio.Getter, error) {
+	opts := []gitignoreio.GetterOption{
+		gitignoreio.WithBaseURL(c.String("base-url")),
+		gitignoreio.WithResolver(settings.resolverOptions(excludeNames)...),
+		gitignoreio.WithMaxRequests(settings.maxRequests),
+	}
+	if source != "" {
+		baseURL, err := gitignoreio.ParseSource(source)
+		if err != nil {
+			return gitignoreio.Getter{}, err
+		}
+		opts = append(opts, gitignoreio.WithBaseURL(baseURL))
+	}
+	return gitignoreio.NewGetter(opts...)
+}
+
+// newIndexGetter returns the getter for the index given by the source, if
+// any, or else by the index URL flag
+func newIndexGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (httpindex.Getter, error) {
+	opts := []httpindex.GetterOption{
+		httpindex.WithIndexURL(c.String("index-url")),
+		httpindex.WithResolver(settings.resolverOptions(excludeNames)...),
+		httpindex.WithMaxRequests(settings.maxRequests),
+	}
+	if source != "" {
+		indexURL, err := httpindex.ParseSource(source)
+		if err != nil {
+			return httpindex.Getter{}, err
+		}
+		opts = append(opts, httpindex.WithIndexURL(indexURL))
+		if !httpindex.SameOrigin(indexURL, c.String("index-url")) {
+			// Indexes may be named by banners and names files from anywhere,
+			// so the token is only sent to the host of the index URL flag
+			return httpindex.NewGetter(opts...)
+		}
+	}
+	opts = append(opts, httpindex.WithToken(c.String("token")))
+	return httpindex.NewGetter(opts...)
+}
+
+// newBundleGetter returns the getter for the bundle given by the source, if
+// any, or else by the bundle flag
+func newBundleGetter(c *cli.Context, source string, settings getterSettings, excludeNames []string) (bundle.Getter, error) {
+	opts := []bundle.GetterOption{
+		bundle.WithPath(c.String("bundle")),
+		bundle.WithResolver(settings.resolverOptions(excludeNames)...),
+	}
+	if source != "" {
+		bundlePath, err := bundle.ParseSource(source)
+		if err != nil {
+			return bundle.Getter{}, err
+		}
+		opts = append(opts, bundle.WithPath(bundlePath))
+	}
+	return bundle.NewGetter(opts...)
+}
+
+// refFlag returns the ref given by the branch flag, which is empty for the
+// default branch of the repository, for sources other than GitHub
+func refFlag(c *cli.Context) string {
+	if !c.IsSet("branch") {
+		return ""
+	}
+	return c.String("branch")
 }
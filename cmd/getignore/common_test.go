@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// newContext returns a context with the common flags parsed from the
+// arguments
+func newContext(args ...string) *cli.Context {
+	set := flag.NewFlagSet("getignore", flag.ContinueOnError)
+	for _, f := range commonFlags {
+		Expect(f.Apply(set)).Should(Succeed())
+	}
+	Expect(set.Parse(args)).Should(Succeed())
+	return cli.NewContext(creatCLI(), set, nil)
+}
+
+var _ = Describe("Common", func() {
+	Describe("gitlabProject", func() {
+		It("should return the project flag", func() {
+			Expect(gitlabProject(newContext("--project", "group/subgroup/templates"))).Should(Equal("group/subgroup/templates"))
+		})
+
+		It("should return the owner and repository flags", func() {
+			Expect(gitlabProject(newContext("--owner", "acme", "--repository", "templates"))).Should(Equal("acme/templates"))
+		})
+
+		It("should require a project, or an owner and a repository", func() {
+			for _, args := range [][]string{nil, {"--owner", "acme"}, {"--repository", "templates"}} {
+				_, err := gitlabProject(newContext(args...))
+				Expect(err).Should(MatchError("--project, or --owner and --repository, is required for the gitlab source"))
+			}
+		})
+	})
+
+	Describe("newGitlabGetter", func() {
+		It("should get from the project of the source without the flags", func() {
+			rs := getignore.RepositorySource{Kind: gitlabSource, Repository: "group/templates"}
+			getter, err := newGitlabGetter(newContext(), rs, getterSettings{}, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getter.Project).Should(Equal("group/templates"))
+		})
+
+		It("should require a project without one in the source", func() {
+			_, err := newGitlabGetter(newContext(), getignore.RepositorySource{Kind: gitlabSource}, getterSettings{}, nil)
+			Expect(err).Should(MatchError(ContainSubstring("--project, or --owner and --repository, is required")))
+		})
+	})
+})
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/embedded"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/github"
+	"github.com/urfave/cli/v2"
+)
+
+// newEmbeddedGetter returns the getter for the snapshot of github/gitignore
+// embedded in getignore, warning that its files may be out of date
+func newEmbeddedGetter(settings getterSettings, excludeNames []string) (bundle.Getter, error) {
+	getter, err := embedded.NewGetter(
+		bundle.WithResolver(settings.resolverOptions(excludeNames)...),
+	)
+	if err != nil {
+		return bundle.Getter{}, err
+	}
+	if err := embedded.Warn(getter); err != nil {
+		return bundle.Getter{}, err
+	}
+	return getter, nil
+}
+
+// newFallbackSourceGetter returns the getter for a source, as newSourceGetter
+// does, falling back to the embedded snapshot when the source is
+// github/gitignore and GitHub can't be reached
+func newFallbackSourceGetter(c *cli.Context, source string, excludeNames []string) (getignore.Source, error) {
+	getter, err := newSourceGetter(c, source, excludeNames)
+	if err != nil || !isUpstreamRepository(getter) || !embedded.Available() {
+		return getter, err
+	}
+	settings, err := loadGetterSettings(c)
+	if err != nil {
+		return nil, err
+	}
+	return embedded.FallbackSource{
+		Source: getter,
+		NewFallback: func() (getignore.Source, error) {
+			return newEmbeddedGetter(settings, excludeNames)
+		},
+	}, nil
+}
+
+// isUpstreamRepository reports whether the getter gets files from
+// github/gitignore on GitHub, of which the embedded snapshot is a copy
+func isUpstreamRepository(getter getignore.Source) bool {
+	if attributed, ok := getter.(attributedSource); ok {
+		getter = attributed.Source
+	}
+	githubGetter, ok := getter.(github.Getter)
+	return ok && githubGetter.BaseURL == "" && githubGetter.Owner == github.Owner && githubGetter.Repository == github.Repository
+}
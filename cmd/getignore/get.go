@@ -1,19 +1,22 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/gotgenes/getignore/pkg/getignore"
-	"github.com/gotgenes/getignore/pkg/github"
 	"github.com/urfave/cli/v2"
 )
 
 var Get = &cli.Command{
 	Name:  "get",
 	Usage: "retrieves gitignore patterns files from a central source, combines them, and outputs them",
-	Flags: append(commonFlags, []cli.Flag{
+	Flags: append(append(commonFlags, []cli.Flag{
 		&cli.StringFlag{
 			Name:    "output-file",
 			Aliases: []string{"o"},
@@ -22,62 +25,176 @@ var Get = &cli.Command{
 		&cli.StringFlag{
 			Name:    "names-file",
 			Aliases: []string{"n"},
-			Usage:   "Path to file containing names of gitignore patterns files",
+			Usage:   "Path to file containing names of gitignore patterns files, or '-' for STDIN",
 		},
-		&cli.IntFlag{
-			Name:    "max-requests",
-			Aliases: []string{"m"},
-			Usage:   "The number of maximum connections to open for HTTP requests",
-			Value:   github.DefaultMaxRequests,
+		&cli.BoolFlag{
+			Name:  "append",
+			Usage: "Add sections to the end of the output file, skipping those already in it",
 		},
-	}...),
-	ArgsUsage: "path [path …]",
+		&cli.BoolFlag{
+			Name:  "managed",
+			Usage: "Write the contents to a section managed by getignore, recording what they were generated from in a lock file",
+		},
+		maxRequestsFlag,
+		&cli.StringSliceFlag{
+			Name:    "match",
+			Aliases: []string{"M"},
+			Usage:   "Get files with paths matching a glob, or a regular expression prefixed with 're:'",
+		},
+		&cli.StringSliceFlag{
+			Name:    "exclude",
+			Aliases: []string{"x"},
+			Usage:   "Leave out files with paths matching a glob, or a regular expression prefixed with 're:'",
+		},
+		&cli.StringSliceFlag{
+			Name:    "add",
+			Aliases: []string{"a"},
+			Usage:   "Add a pattern inline, in a section in its place among the names",
+		},
+		&cli.BoolFlag{
+			Name:  "fuzzy",
+			Usage: "Resolve names not found in the repository to their closest unambiguous match",
+		},
+	}...), outputFlags...),
+	ArgsUsage: "name|pattern|file [name|pattern|file …]",
 	Action:    getFiles,
 }
 
 func getFiles(ctx *cli.Context) error {
-	names := getNamesFromArguments(ctx)
-	getter, err := newGithubGetter(ctx)
+	if ctx.String("output-file") == "" {
+		for _, flagName := range []string{"append", "backup", "dry-run", "diff", "managed"} {
+			if ctx.Bool(flagName) {
+				return fmt.Errorf("--%s requires --output-file", flagName)
+			}
+		}
+	}
+	if ctx.Bool("managed") {
+		return getManagedFile(ctx)
+	}
+	namesList, err := getNamesFromArguments(ctx)
 	if err != nil {
 		return err
 	}
-	contents, err := getter.Get(ctx.Context, names)
+	newGetter := func(source string) (getignore.Getter, error) {
+		return newFallbackSourceGetter(ctx, source, namesList.Exclusions)
+	}
+	contents, err := getignore.GetContents(ctx.Context, newGetter, namesList.Names)
 	if err != nil {
 		return err
 	}
-	outputFilePath, outputFile, err := getOutputFile(ctx)
+	return writeOutputFile(ctx, contents)
+}
+
+// getManagedFile writes the contents to a section of the output file managed
+// by getignore
+func getManagedFile(c *cli.Context) error {
+	if c.String("names-file") == getignore.StdinNamesFile {
+		return errors.New("--managed requires names to be read from a file, not STDIN")
+	}
+	names, err := nameArguments(c)
 	if err != nil {
 		return err
 	}
-	log.Println("Writing contents to", outputFilePath)
-	err = getignore.WriteIgnoreFile(outputFile, contents)
+	banner := getignore.Banner{
+		Source:    defaultSource(c),
+		Names:     names,
+		NamesFile: c.String("names-file"),
+	}
+	namesList, contents, err := getManagedContents(c, banner)
 	if err != nil {
 		return err
 	}
-	return nil
+	return writeManagedFile(c, c.String("output-file"), banner, namesList, contents, c.Bool("diff"))
 }
 
-func getNamesFromArguments(c *cli.Context) []string {
-	names := c.Args().Slice()
-
+func getNamesFromArguments(c *cli.Context) (getignore.NamesList, error) {
+	var namesList getignore.NamesList
 	if c.String("names-file") != "" {
-		namesFile, _ := os.Open(c.String("names-file"))
-		names = append(names, getignore.ParseNamesFile(namesFile)...)
+		var err error
+		namesList, err = getignore.ReadNamesFile(c.String("names-file"))
+		if err != nil {
+			return getignore.NamesList{}, err
+		}
+	}
+	names, err := nameArguments(c)
+	if err != nil {
+		return getignore.NamesList{}, err
 	}
-	return names
+	namesList.Names = append(names, namesList.Names...)
+	return namesList, nil
 }
 
-func getOutputFile(c *cli.Context) (string, io.Writer, error) {
-	outputFilePath := c.String("o")
-	var (
-		outputFile io.Writer
-		err        error
-	)
+// addFlagNames are the ways of giving the add flag among the arguments
+var addFlagNames = map[string]bool{"-a": true, "--a": true, "-add": true, "--add": true}
+
+// nameArguments returns the names given as arguments, followed by those
+// given with --match, with the patterns given with --add in their places
+// among them, as names for which getignore.IsInlineName is true. Flags are
+// only parsed before the first argument, so --add is looked for among the
+// arguments, too.
+func nameArguments(c *cli.Context) ([]string, error) {
+	var names []string
+	for _, pattern := range c.StringSlice("add") {
+		names = append(names, getignore.InlineName(pattern))
+	}
+	args := c.Args().Slice()
+	for i := 0; i < len(args); i++ {
+		flagName, pattern := args[i], ""
+		hasPattern := false
+		if j := strings.Index(flagName, "="); j >= 0 {
+			flagName, pattern, hasPattern = flagName[:j], flagName[j+1:], true
+		}
+		if !addFlagNames[flagName] {
+			names = append(names, args[i])
+			continue
+		}
+		if !hasPattern {
+			if i == len(args)-1 {
+				return nil, fmt.Errorf("flag needs an argument: %s", flagName)
+			}
+			i++
+			pattern = args[i]
+		}
+		names = append(names, getignore.InlineName(pattern))
+	}
+	return append(names, c.StringSlice("match")...), nil
+}
+
+// writeOutputFile writes the contents to STDOUT, or replaces the output file
+// with them, appending them to its existing contents if asked to
+func writeOutputFile(c *cli.Context, contents []getignore.NamedContents) error {
+	outputFilePath := c.String("output-file")
 	if outputFilePath == "" {
-		outputFilePath = "STDOUT"
-		outputFile = os.Stdout
-	} else {
-		outputFile, err = os.Create(outputFilePath)
+		log.Println("Writing contents to STDOUT")
+		return getignore.WriteIgnoreFile(os.Stdout, contents)
+	}
+	write := func(w io.Writer) error {
+		return getignore.WriteIgnoreFile(w, contents)
+	}
+	if c.Bool("append") {
+		existing, err := os.ReadFile(outputFilePath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		existingSections := make(map[string]bool)
+		for _, name := range getignore.SectionNames(string(existing)) {
+			existingSections[name] = true
+		}
+		var newContents []getignore.NamedContents
+		for _, nc := range contents {
+			if existingSections[nc.DisplayName()] {
+				log.Printf("Skipping %s, already in %s", nc.DisplayName(), outputFilePath)
+				continue
+			}
+			newContents = append(newContents, nc)
+		}
+		if len(newContents) == 0 {
+			log.Println("Nothing to append to", outputFilePath)
+			return nil
+		}
+		write = func(w io.Writer) error {
+			return getignore.AppendIgnoreFile(w, string(existing), newContents)
+		}
 	}
-	return outputFilePath, outputFile, err
+	return writeOrPreview(c, outputFilePath, c.Bool("diff"), write)
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var History = &cli.Command{
+	Name:  "history",
+	Usage: "lists the commits that changed a gitignore patterns file in its repository",
+	Flags: append(commonFlags, []cli.Flag{
+		&cli.IntFlag{
+			Name:    "limit",
+			Aliases: []string{"L"},
+			Usage:   "The maximum number of commits to list",
+			Value:   20,
+		},
+		&cli.StringFlag{
+			Name:  "diff",
+			Usage: "Show the changes between two revisions, given as OLD..NEW, where NEW defaults to the branch",
+		},
+	}...),
+	ArgsUsage: "name",
+	Action:    showHistory,
+}
+
+func showHistory(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("history requires exactly one name")
+	}
+	getter, err := newGithubSourceGetter(c, "")
+	if err != nil {
+		return err
+	}
+	if c.String("diff") == "" {
+		_, commits, err := getter.History(c.Context, c.Args().First(), c.Int("limit"))
+		if err != nil {
+			return err
+		}
+		return getignore.WriteCommits(os.Stdout, commits)
+	}
+	oldRevision, newRevision, err := getignore.ParseRevisionRange(c.String("diff"))
+	if err != nil {
+		return err
+	}
+	if newRevision == "" {
+		newRevision = getter.Branch
+	}
+	filePath, err := getter.Resolve(c.Context, c.Args().First())
+	if err != nil {
+		return err
+	}
+	oldContents, err := getter.GetAt(c.Context, filePath, oldRevision)
+	if err != nil {
+		return err
+	}
+	newContents, err := getter.GetAt(c.Context, filePath, newRevision)
+	if err != nil {
+		return err
+	}
+	fmt.Print(getignore.UnifiedDiff(
+		fmt.Sprintf("a/%s@%s", filePath, oldRevision),
+		fmt.Sprintf("b/%s@%s", filePath, newRevision),
+		oldContents,
+		newContents,
+	))
+	return nil
+}
@@ -2,30 +2,66 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"os"
 
+	"github.com/gotgenes/getignore/pkg/getignore"
 	"github.com/urfave/cli/v2"
 )
 
 var List = &cli.Command{
-	Name:   "list",
-	Usage:  "lists available gitignore patterns files",
-	Flags:  commonFlags,
+	Name:  "list",
+	Usage: "lists available gitignore patterns files",
+	Flags: append(append(commonFlags, newFilterFlags("List")...), []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "tree",
+			Aliases: []string{"t"},
+			Usage:   "List files as a tree of directories",
+		},
+		&cli.BoolFlag{
+			Name:    "long",
+			Aliases: []string{"l"},
+			Usage:   "List the size and SHA of each file",
+		},
+	}...),
 	Action: listIgnoreFiles,
 }
 
 func listIgnoreFiles(c *cli.Context) error {
-	getter, err := newGithubGetter(c)
+	filters, err := getignore.ParsePatterns(c.StringSlice("filter"))
+	if err != nil {
+		return err
+	}
+	getter, err := newFallbackSourceGetter(c, "", nil)
 	if err != nil {
 		return err
 	}
 	ctx := context.Background()
-	ignoreFiles, err := getter.List(ctx)
+	if layeredSource, ok := getter.(getignore.LayeredSource); ok && !c.Bool("tree") {
+		return listLayeredEntries(ctx, layeredSource, filters, c.String("category"), c.Bool("long"))
+	}
+	entries, err := getter.ListEntries(ctx)
 	if err != nil {
 		return err
 	}
-	outputString := strings.Join(ignoreFiles, "\n")
-	_, err = fmt.Println(outputString)
-	return err
+	entries = getignore.FilterEntries(entries, filters, c.String("category"))
+	if c.Bool("tree") {
+		return getignore.WriteTreeListing(os.Stdout, entries, c.Bool("long"))
+	}
+	return getignore.WriteListing(os.Stdout, entries, c.Bool("long"))
+}
+
+// listLayeredEntries lists the files of the layered source matching the
+// filters and in the category, along with the layer each comes from
+func listLayeredEntries(ctx context.Context, source getignore.LayeredSource, filters []getignore.Pattern, category string, long bool) error {
+	layeredEntries, err := source.ListLayeredEntries(ctx)
+	if err != nil {
+		return err
+	}
+	var filtered []getignore.LayeredEntry
+	for _, entry := range layeredEntries {
+		if len(getignore.FilterEntries([]getignore.FileEntry{entry.FileEntry}, filters, category)) > 0 {
+			filtered = append(filtered, entry)
+		}
+	}
+	return getignore.WriteLayeredListing(os.Stdout, filtered, long)
 }
@@ -24,6 +24,15 @@ func creatCLI() *cli.App {
 	app.Version = getignore.Version
 	app.Usage = "Bootstraps gitignore files from central sources"
 	app.EnableBashCompletion = true
-	app.Commands = []*cli.Command{List, Get}
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "Path to the configuration file",
+			EnvVars: []string{"GETIGNORE_CONFIG"},
+			Value:   getignore.DefaultConfigPath(),
+		},
+	}
+	app.Commands = []*cli.Command{List, Get, Update, Check, Outdated, Show, History, Blame, Search, Which, Bundle}
 	return app
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+)
+
+// flagNames returns the name of the command's flag for each of the names and
+// aliases of its flags
+func flagNames(command *cli.Command) map[string][]string {
+	names := make(map[string][]string)
+	for _, flag := range command.Flags {
+		for _, name := range flag.Names() {
+			names[name] = append(names[name], flag.Names()[0])
+		}
+	}
+	return names
+}
+
+var _ = Describe("getignore", func() {
+	It("should give each flag of a command its own names and aliases", func() {
+		for _, command := range creatCLI().Commands {
+			for name, flags := range flagNames(command) {
+				Expect(flags).Should(HaveLen(1), "%s has several flags named %s: %v", command.Name, name, flags)
+			}
+		}
+	})
+
+	It("should give each name and alias of a flag the same meaning in every command", func() {
+		meanings := make(map[string]string)
+		for _, command := range creatCLI().Commands {
+			for name, flags := range flagNames(command) {
+				if meaning, ok := meanings[name]; ok {
+					Expect(flags[0]).Should(Equal(meaning), "-%s of %s is --%s, but --%s elsewhere", name, command.Name, flags[0], meaning)
+				}
+				meanings[name] = flags[0]
+			}
+		}
+	})
+
+	It("should number the lines shown with -N, as -n is the names file", func() {
+		Expect(flagNames(Show)).Should(HaveKeyWithValue("N", []string{"line-numbers"}))
+		Expect(flagNames(Get)).Should(HaveKeyWithValue("n", []string{"names-file"}))
+	})
+})
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/embedded"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/github"
+	"github.com/gotgenes/getignore/pkg/gitignoreio"
+	"github.com/gotgenes/getignore/pkg/gitremote"
+	"github.com/gotgenes/getignore/pkg/httpindex"
+	"github.com/urfave/cli/v2"
+)
+
+// defaultSource returns the source given by the source, owner, repository,
+// project, and branch flags, or the layered source if files come from the
+// layers in the configuration
+func defaultSource(c *cli.Context) string {
+	if settings, err := loadGetterSettings(c); err == nil && usesLayers(c, settings) {
+		return getignore.LayeredSourceName
+	}
+	return kindSource(c, c.String("source"))
+}
+
+// kindSource returns the source for the repository on the kind of host given
+// by the owner, repository, project, and branch flags
+func kindSource(c *cli.Context, kind string) string {
+	switch kind {
+	case gitlabSource:
+		// Getters for GitLab fail without a project, before it is recorded
+		project, _ := gitlabProject(c)
+		return getignore.RepositorySource{Kind: kind, Repository: project, Ref: refFlag(c)}.String()
+	case giteaSource, bitbucketSource, bitbucketDataCenterSource:
+		return getignore.RepositorySource{Kind: kind, Owner: c.String("owner"), Repository: c.String("repository"), Ref: refFlag(c)}.String()
+	case gitSource:
+		return gitremote.FormatSource(c.String("remote"), refFlag(c))
+	case gitignoreioSource:
+		if c.String("base-url") == "" {
+			return gitignoreio.FormatSource(gitignoreio.BaseURL)
+		}
+		return gitignoreio.FormatSource(c.String("base-url"))
+	case indexSource:
+		return httpindex.FormatSource(c.String("index-url"))
+	case bundleSource:
+		return bundle.FormatSource(c.String("bundle"))
+	case embeddedSource:
+		return embedded.SourceName
+	}
+	rs := getignore.RepositorySource{Owner: c.String("owner"), Repository: c.String("repository"), Ref: github.Branch}
+	if c.IsSet("branch") {
+		rs.Ref = c.String("branch")
+	}
+	if kind == githubSource && c.String("source") != githubSource {
+		// Sources without a kind are of the kind given by the source flag
+		rs.Kind = githubSource
+	}
+	return rs.String()
+}
+
+// bannerNamesList returns the names recorded by the banner, along with those
+// currently in its names file
+func bannerNamesList(banner getignore.Banner) (getignore.NamesList, error) {
+	namesList := getignore.NamesList{Names: banner.Names}
+	if banner.NamesFile != "" {
+		fileNamesList, err := getignore.ReadNamesFile(banner.NamesFile)
+		if err != nil {
+			return getignore.NamesList{}, err
+		}
+		namesList.Names = append(append([]string{}, namesList.Names...), fileNamesList.Names...)
+		namesList.Exclusions = fileNamesList.Exclusions
+	}
+	return namesList, nil
+}
+
+// getManagedContents gets the contents for the managed section the banner
+// describes
+func getManagedContents(c *cli.Context, banner getignore.Banner) (getignore.NamesList, []getignore.NamedContents, error) {
+	namesList, err := bannerNamesList(banner)
+	if err != nil {
+		return getignore.NamesList{}, nil, err
+	}
+	newGetter := func(source string) (getignore.Getter, error) {
+		if source == "" {
+			source = banner.Source
+		}
+		return newFallbackSourceGetter(c, source, namesList.Exclusions)
+	}
+	contents, err := getignore.GetContents(c.Context, newGetter, namesList.Names)
+	if err != nil {
+		return getignore.NamesList{}, nil, err
+	}
+	if len(banner.Add) > 0 {
+		contents = append(contents, getignore.NewInlineContents(banner.Add))
+	}
+	return namesList, contents, nil
+}
+
+// writeManagedFile replaces the managed section of the file at the path, or
+// the whole file if it has none, with one made up of the banner and
+// contents, or appends it to the file if asked to, then writes the lock file.
+// In a dry run, it shows how the file would change if showDiff is true.
+func writeManagedFile(c *cli.Context, path string, banner getignore.Banner, namesList getignore.NamesList, contents []getignore.NamedContents, showDiff bool) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	mf, ok := getignore.ParseManagedFile(string(existing))
+	if !ok && c.Bool("append") && len(existing) > 0 {
+		mf.Before = strings.TrimRight(string(existing), "\n") + "\n\n\n"
+	}
+	mf.Banner = banner
+	mf.Sections = contents
+	err = writeOrPreview(c, path, showDiff, func(w io.Writer) error {
+		return getignore.WriteManagedFile(w, mf)
+	})
+	if err != nil || c.Bool("dry-run") || c.Bool("diff") {
+		return err
+	}
+	lock := getignore.NewLock(namesList, contents, banner.Source)
+	return getignore.WriteFileAtomic(path+getignore.LockFileSuffix, func(w io.Writer) error {
+		return getignore.WriteLock(w, lock)
+	}, false)
+}
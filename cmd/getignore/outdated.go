@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Outdated = &cli.Command{
+	Name:  "outdated",
+	Usage: "lists gitignore patterns files in a lock file, or managed section, that have changed in their sources",
+	Flags: append(commonFlags, []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "diff",
+			Usage: "Show the changes to each file",
+		},
+	}...),
+	ArgsUsage: "[path]",
+	Action:    listOutdated,
+}
+
+func listOutdated(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return errors.New("outdated accepts at most one path")
+	}
+	path := ".gitignore"
+	if c.NArg() == 1 {
+		path = c.Args().First()
+	}
+	lockedFiles, sectionBlobs, err := readLockedFiles(c, path)
+	if err != nil {
+		return err
+	}
+	var (
+		sources        []string
+		sourcesToFiles = make(map[string][]getignore.LockedFile)
+	)
+	for _, lf := range lockedFiles {
+		if lf.Source == getignore.LocalSource {
+			continue
+		}
+		if _, ok := sourcesToFiles[lf.Source]; !ok {
+			sources = append(sources, lf.Source)
+		}
+		sourcesToFiles[lf.Source] = append(sourcesToFiles[lf.Source], lf)
+	}
+	var numOutdated int
+	for _, source := range sources {
+		getter, err := newBlobSourceGetter(c, source)
+		if err != nil {
+			return err
+		}
+		entries, err := getter.ListEntries(c.Context)
+		if err != nil {
+			return err
+		}
+		outdated := getignore.FindOutdated(sourcesToFiles[source], entries)
+		numOutdated += len(outdated)
+		if err := getignore.WriteOutdated(os.Stdout, outdated); err != nil {
+			return err
+		}
+		if !c.Bool("diff") {
+			continue
+		}
+		for _, f := range outdated {
+			if f.Removed() {
+				continue
+			}
+			locked, ok := sectionBlobs[f.SHA]
+			if !ok {
+				locked, err = getter.GetBlob(c.Context, f.SHA)
+				if err != nil {
+					return err
+				}
+			}
+			latest, err := getter.GetBlob(c.Context, f.LatestSHA)
+			if err != nil {
+				return err
+			}
+			fmt.Print(getignore.UnifiedDiff("a/"+f.Path, "b/"+f.Path, locked, latest))
+		}
+	}
+	if numOutdated > 0 {
+		return fmt.Errorf("%d gitignore patterns files have changed; run `getignore update` to update %s", numOutdated, path)
+	}
+	log.Println("All gitignore patterns files in", path, "are up to date")
+	return nil
+}
+
+// readLockedFiles returns the files in the lock file of the gitignore file at
+// the path, or, without a lock file, the files its managed section was
+// generated from, as given by getignore.ManagedSectionFiles, along with the
+// contents of their sections by SHA
+func readLockedFiles(c *cli.Context, path string) ([]getignore.LockedFile, map[string]string, error) {
+	lock, err := getignore.ReadLock(path + getignore.LockFileSuffix)
+	if err == nil {
+		return lock.Files, nil, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	mf, ok := getignore.ParseManagedFile(string(contents))
+	if !ok {
+		return nil, nil, fmt.Errorf("%s has neither a lock file nor a managed section recording the sources of its files; run `getignore get --managed`", path)
+	}
+	log.Println("No lock file for", path+"; comparing its sections with the files its banner names")
+	_, current, err := getManagedContents(c, mf.Banner)
+	if err != nil {
+		return nil, nil, err
+	}
+	sectionBlobs := make(map[string]string, len(mf.Sections))
+	for _, section := range mf.Sections {
+		blob := getignore.SectionContents(section)
+		sectionBlobs[getignore.BlobSHA(blob)] = blob
+	}
+	return getignore.ManagedSectionFiles(mf, current, mf.Banner.Source), sectionBlobs, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Search = &cli.Command{
+	Name:  "search",
+	Usage: "searches the contents of all available gitignore patterns files",
+	Flags: append(append(commonFlags, newFilterFlags("Search")...), []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "regex",
+			Aliases: []string{"E"},
+			Usage:   "Treat the pattern as a regular expression",
+		},
+		&cli.BoolFlag{
+			Name:    "ignore-case",
+			Aliases: []string{"i"},
+			Usage:   "Ignore case when matching the pattern",
+		},
+		maxRequestsFlag,
+	}...),
+	ArgsUsage: "pattern",
+	Action:    searchIgnoreFiles,
+}
+
+func searchIgnoreFiles(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("search requires exactly one pattern")
+	}
+	match, err := getignore.NewLineMatcher(c.Args().First(), c.Bool("regex"), c.Bool("ignore-case"))
+	if err != nil {
+		return err
+	}
+	getter, err := newFallbackSourceGetter(c, "", nil)
+	if err != nil {
+		return err
+	}
+	contents, failedFiles, err := getFilteredContents(c, getter)
+	if err != nil {
+		return err
+	}
+	if err := getignore.WriteSearchResults(os.Stdout, getignore.Search(contents, match)); err != nil {
+		return err
+	}
+	return reportFailedFiles(failedFiles, "search")
+}
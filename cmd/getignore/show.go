@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Show = &cli.Command{
+	Name:  "show",
+	Usage: "shows the contents and details of a gitignore patterns file",
+	Flags: append(commonFlags, []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "line-numbers",
+			Aliases: []string{"N"},
+			Usage:   "Number the lines of the contents",
+		},
+		colorFlag,
+	}...),
+	ArgsUsage: "name",
+	Action:    showIgnoreFile,
+}
+
+func showIgnoreFile(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("show requires exactly one name")
+	}
+	highlight, err := useColor(c, os.Stdout)
+	if err != nil {
+		return err
+	}
+	getter, err := newGithubSourceGetter(c, "")
+	if err != nil {
+		return err
+	}
+	details, err := getter.Describe(c.Context, c.Args().First())
+	if err != nil {
+		return err
+	}
+	opts := getignore.DisplayOptions{
+		LineNumbers: c.Bool("line-numbers"),
+		Highlight:   highlight,
+	}
+	return getignore.WriteFileDetails(os.Stdout, details, opts)
+}
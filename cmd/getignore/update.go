@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Update = &cli.Command{
+	Name:  "update",
+	Usage: "updates the sections of a gitignore file with the latest contents of their gitignore patterns files",
+	Flags: append(append(commonFlags, []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output-file",
+			Aliases: []string{"o"},
+			Usage:   "Path to the gitignore file to update",
+			Value:   ".gitignore",
+		},
+		maxRequestsFlag,
+	}...), outputFlags...),
+	Action: updateFile,
+}
+
+func updateFile(c *cli.Context) error {
+	path := c.String("output-file")
+	// Dry runs show the changes, as there is no other way to see what update
+	// would change
+	showDiff := c.Bool("dry-run") || c.Bool("diff")
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if mf, ok := getignore.ParseManagedFile(string(existing)); ok {
+		namesList, contents, err := getManagedContents(c, mf.Banner)
+		if err != nil {
+			return err
+		}
+		return writeManagedFile(c, path, mf.Banner, namesList, contents, showDiff)
+	}
+	preamble, sections := getignore.ParseSections(string(existing))
+	getter, err := newFallbackSourceGetter(c, "", nil)
+	if err != nil {
+		return err
+	}
+	entries, err := getter.ListEntries(c.Context)
+	if err != nil {
+		return err
+	}
+	sectionPaths, err := getignore.FindSectionPaths(sections, entries)
+	if err != nil {
+		return fmt.Errorf("unable to update %s: %w", path, err)
+	}
+	var paths []string
+	for i, p := range sectionPaths {
+		if p != "" {
+			paths = append(paths, p)
+		} else if name := sections[i].DisplayName(); name != getignore.InlineContentsName {
+			log.Printf("Keeping %s unchanged: not present in file tree", name)
+		}
+	}
+	contents, err := getter.Get(c.Context, paths)
+	var failedFiles getignore.FailedFiles
+	if err != nil && !errors.As(err, &failedFiles) {
+		return err
+	}
+	for _, failedFile := range failedFiles {
+		log.Printf("Keeping %s unchanged: %v", failedFile.Name, failedFile)
+	}
+	latest := make(map[string]getignore.NamedContents, len(contents))
+	for _, nc := range contents {
+		latest[nc.Name] = nc
+	}
+	for i, p := range sectionPaths {
+		if nc, ok := latest[p]; ok {
+			sections[i] = nc
+		}
+	}
+	return writeOrPreview(c, path, showDiff, func(w io.Writer) error {
+		return getignore.AppendIgnoreFile(w, preamble, sections)
+	})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/urfave/cli/v2"
+)
+
+var Which = &cli.Command{
+	Name:  "which",
+	Usage: "finds gitignore patterns files that would ignore a path",
+	Flags: append(append(commonFlags, newFilterFlags("Consider")...), []cli.Flag{
+		maxRequestsFlag,
+	}...),
+	ArgsUsage: "path",
+	Action:    whichIgnoreFiles,
+}
+
+func whichIgnoreFiles(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("which requires exactly one path")
+	}
+	path := c.Args().First()
+	getter, err := newFallbackSourceGetter(c, "", nil)
+	if err != nil {
+		return err
+	}
+	contents, failedFiles, err := getFilteredContents(c, getter)
+	if err != nil {
+		return err
+	}
+	results := getignore.Which(contents, path)
+	if len(results) == 0 && len(failedFiles) == 0 {
+		return fmt.Errorf("no gitignore patterns files ignore %s", path)
+	}
+	if err := getignore.WriteSearchResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return reportFailedFiles(failedFiles, "evaluate")
+}
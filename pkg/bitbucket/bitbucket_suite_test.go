@@ -0,0 +1,13 @@
+package bitbucket_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBitbucket(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bitbucket Suite")
+}
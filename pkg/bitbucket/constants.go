@@ -0,0 +1,13 @@
+package bitbucket
+
+const (
+	// CloudBaseURL is the URL of the Bitbucket Cloud API
+	CloudBaseURL = "https://api.bitbucket.org"
+	Suffix       = ".gitignore"
+
+	cloudAPIPath      = "/2.0"
+	dataCenterAPIPath = "/rest/api/1.0"
+	// cloudMaxDepth is how many directories deep Bitbucket Cloud lists files
+	cloudMaxDepth = 10
+	pageSize      = 100
+)
@@ -0,0 +1,420 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = getignore.DefaultMaxRequests
+
+// Getter lists and gets files using the source API of Bitbucket Cloud, or
+// the files and raw APIs of Bitbucket Data Center.
+type Getter struct {
+	client *http.Client
+	// BaseURL is the URL of the Bitbucket Cloud API, or of the Bitbucket Data
+	// Center instance, without the API path
+	BaseURL string
+	// DataCenter is whether BaseURL is a Bitbucket Data Center instance
+	DataCenter bool
+	// Owner is the workspace, on Bitbucket Cloud, or the project key, on
+	// Bitbucket Data Center, of the repository
+	Owner      string
+	Repository string
+	// Ref is the branch, tag, or commit to list files at, or empty for the
+	// default branch of the repository
+	Ref string
+	// Username is the user to authenticate as with Token as the password,
+	// e.g., for an app password, or empty to send Token as a bearer token,
+	// e.g., for an HTTP access token
+	Username    string
+	Token       string
+	MaxRequests int
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	client      *http.Client
+	baseURL     string
+	dataCenter  bool
+	owner       string
+	repository  string
+	ref         string
+	username    string
+	token       string
+	maxRequests int
+	resolver    getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		client:      http.DefaultClient,
+		resolver:    getignore.ResolverSettings{Suffix: Suffix},
+		maxRequests: DefaultMaxRequests,
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.baseURL == "" {
+		if params.dataCenter {
+			return Getter{}, errors.New("a Bitbucket Data Center base URL is required")
+		}
+		params.baseURL = CloudBaseURL
+	}
+	if _, err := url.Parse(params.baseURL); err != nil {
+		return Getter{}, fmt.Errorf("invalid Bitbucket base URL: %w", err)
+	}
+	if params.owner == "" || params.repository == "" {
+		return Getter{}, errors.New("a Bitbucket workspace or project key, and repository, are required")
+	}
+	return Getter{
+		client:           params.client,
+		BaseURL:          strings.TrimSuffix(params.baseURL, "/"),
+		DataCenter:       params.dataCenter,
+		Owner:            params.owner,
+		Repository:       params.repository,
+		Ref:              params.ref,
+		Username:         params.username,
+		Token:            params.token,
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithClient sets the HTTP client for the Getter
+func WithClient(client *http.Client) GetterOption {
+	return func(p *getterParams) {
+		p.client = client
+	}
+}
+
+// WithBaseURL sets the URL of the Bitbucket Cloud API, or of the Bitbucket
+// Data Center instance, for the Getter
+func WithBaseURL(baseURL string) GetterOption {
+	return func(p *getterParams) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithDataCenter sets whether the Getter uses the Bitbucket Data Center API
+// rather than the Bitbucket Cloud API
+func WithDataCenter(dataCenter bool) GetterOption {
+	return func(p *getterParams) {
+		p.dataCenter = dataCenter
+	}
+}
+
+// WithOwner sets the workspace or project key for the Getter
+func WithOwner(owner string) GetterOption {
+	return func(p *getterParams) {
+		p.owner = owner
+	}
+}
+
+// WithRepository sets the repository slug for the Getter
+func WithRepository(repository string) GetterOption {
+	return func(p *getterParams) {
+		p.repository = repository
+	}
+}
+
+// WithRef sets the branch, tag, or commit for the Getter
+func WithRef(ref string) GetterOption {
+	return func(p *getterParams) {
+		p.ref = ref
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// WithUsername sets the user to authenticate as, with the token as the
+// password
+func WithUsername(username string) GetterOption {
+	return func(p *getterParams) {
+		p.username = username
+	}
+}
+
+// WithToken sets the app password, or HTTP access token, to authenticate
+// with
+func WithToken(token string) GetterOption {
+	return func(p *getterParams) {
+		p.token = token
+	}
+}
+
+// WithMaxRequests sets the number of maximum concurrent HTTP requests
+func WithMaxRequests(max int) GetterOption {
+	return func(p *getterParams) {
+		p.maxRequests = max
+	}
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix. Bitbucket does not report the SHAs of files, nor, on
+// Data Center, their sizes, so those are left empty.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	_, files, err := g.listFiles(ctx)
+	if err != nil {
+		return nil, g.newListError(err)
+	}
+	var entries []getignore.FileEntry
+	for _, entry := range files {
+		if strings.HasSuffix(entry.Path, g.Suffix) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files downloaded from the given names
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	ref, files, err := g.listFiles(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	names, unresolvedFiles := g.Resolver().Resolve(names, getignore.EntryPaths(files))
+	namedContents, failedFiles := getignore.DownloadFiles(ctx, names, g.MaxRequests, func(ctx context.Context, name string) (string, error) {
+		return g.getRaw(ctx, name, ref)
+	})
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+func (g Getter) newListError(err error) error {
+	return fmt.Errorf("error listing contents of %s/%s at %s: %w", g.Owner, g.Repository, g.refName(), err)
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s/%s at %s: %w", g.Owner, g.Repository, g.refName(), err)
+}
+
+// refName names the ref for messages
+func (g Getter) refName() string {
+	if g.Ref == "" {
+		return "the default branch"
+	}
+	return g.Ref
+}
+
+// listFiles lists all the files in the repository, returning the ref to get
+// them at
+func (g Getter) listFiles(ctx context.Context) (string, []getignore.FileEntry, error) {
+	if g.DataCenter {
+		files, err := g.listDataCenterFiles(ctx)
+		return g.Ref, files, err
+	}
+	ref, err := g.getCloudRef(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	files, err := g.listCloudFiles(ctx, ref)
+	return ref, files, err
+}
+
+// getCloudRef returns the ref to get files at, looking up the main branch of
+// the repository if no ref was given, as Bitbucket Cloud requires one
+func (g Getter) getCloudRef(ctx context.Context) (string, error) {
+	if g.Ref != "" {
+		return g.Ref, nil
+	}
+	body, err := g.get(ctx, g.apiURL("", nil))
+	if err != nil {
+		return "", fmt.Errorf("unable to get repository information: %w", err)
+	}
+	var repository struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return "", fmt.Errorf("unable to get repository information: %w", err)
+	}
+	if repository.MainBranch.Name == "" {
+		return "", errors.New("no main branch information received")
+	}
+	return repository.MainBranch.Name, nil
+}
+
+// listCloudFiles lists the files at the ref. Bitbucket Cloud lists files
+// only so many directories deep, so the directories it lists without their
+// contents are listed in turn.
+func (g Getter) listCloudFiles(ctx context.Context, ref string) ([]getignore.FileEntry, error) {
+	var files []getignore.FileEntry
+	for dirs := []string{""}; len(dirs) > 0; dirs = dirs[1:] {
+		dirFiles, unlistedDirs, err := g.listCloudDirectory(ctx, ref, dirs[0])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
+		dirs = append(dirs, unlistedDirs...)
+	}
+	return files, nil
+}
+
+// listCloudDirectory lists the files in the directory at the ref, following
+// the pages of results, along with the directories listed without their
+// contents, as they are deeper than Bitbucket Cloud lists at once
+func (g Getter) listCloudDirectory(ctx context.Context, ref string, dir string) ([]getignore.FileEntry, []string, error) {
+	var (
+		files  []getignore.FileEntry
+		dirs   []string
+		listed = make(map[string]bool)
+	)
+	query := url.Values{
+		"max_depth": {strconv.Itoa(cloudMaxDepth)},
+		"pagelen":   {strconv.Itoa(pageSize)},
+	}
+	resource := "src/" + url.PathEscape(ref) + "/"
+	if dir != "" {
+		resource += escapePath(dir) + "/"
+	}
+	for u := g.apiURL(resource, query); u != ""; {
+		body, err := g.get(ctx, u)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get file information: %w", err)
+		}
+		var page struct {
+			Values []struct {
+				Path string `json:"path"`
+				Type string `json:"type"`
+				Size int    `json:"size"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, nil, fmt.Errorf("unable to get file information: %w", err)
+		}
+		for _, value := range page.Values {
+			switch value.Type {
+			case "commit_file":
+				files = append(files, getignore.FileEntry{Path: value.Path, Size: value.Size})
+			case "commit_directory":
+				dirs = append(dirs, value.Path)
+			}
+			listed[path.Dir(value.Path)] = true
+		}
+		u = page.Next
+	}
+	var unlistedDirs []string
+	for _, d := range dirs {
+		if !listed[d] {
+			unlistedDirs = append(unlistedDirs, d)
+		}
+	}
+	return files, unlistedDirs, nil
+}
+
+// listDataCenterFiles lists the files at the ref, or on the default branch,
+// following the pages of results
+func (g Getter) listDataCenterFiles(ctx context.Context) ([]getignore.FileEntry, error) {
+	var files []getignore.FileEntry
+	query := url.Values{"limit": {strconv.Itoa(pageSize)}}
+	if g.Ref != "" {
+		query.Set("at", g.Ref)
+	}
+	for start, lastPage := 0, false; !lastPage; {
+		query.Set("start", strconv.Itoa(start))
+		body, err := g.get(ctx, g.apiURL("files", query))
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file information: %w", err)
+		}
+		var page struct {
+			Values        []string `json:"values"`
+			IsLastPage    bool     `json:"isLastPage"`
+			NextPageStart int      `json:"nextPageStart"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("unable to get file information: %w", err)
+		}
+		for _, value := range page.Values {
+			files = append(files, getignore.FileEntry{Path: value})
+		}
+		start, lastPage = page.NextPageStart, page.IsLastPage || len(page.Values) == 0
+	}
+	return files, nil
+}
+
+// getRaw gets the contents of the file at the path as of the ref
+func (g Getter) getRaw(ctx context.Context, filePath string, ref string) (string, error) {
+	escapedPath := escapePath(filePath)
+	var u string
+	if g.DataCenter {
+		var query url.Values
+		if ref != "" {
+			query = url.Values{"at": {ref}}
+		}
+		u = g.apiURL("raw/"+escapedPath, query)
+	} else {
+		u = g.apiURL("src/"+url.PathEscape(ref)+"/"+escapedPath, nil)
+	}
+	body, err := g.get(ctx, u)
+	return string(body), err
+}
+
+// escapePath escapes each segment of the path of a file or directory in the
+// repository for use in a URL
+func escapePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// apiURL returns the URL of the resource of the repository with the query
+func (g Getter) apiURL(resource string, query url.Values) string {
+	var u string
+	if g.DataCenter {
+		u = fmt.Sprintf("%s%s/projects/%s/repos/%s", g.BaseURL, dataCenterAPIPath, url.PathEscape(g.Owner), url.PathEscape(g.Repository))
+	} else {
+		u = fmt.Sprintf("%s%s/repositories/%s/%s", g.BaseURL, cloudAPIPath, url.PathEscape(g.Owner), url.PathEscape(g.Repository))
+	}
+	if resource != "" {
+		u += "/" + resource
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// get requests the URL, returning the body of the response
+func (g Getter) get(ctx context.Context, u string) ([]byte, error) {
+	body, _, err := getignore.HTTPGet(ctx, g.client, u, func(req *http.Request) {
+		if g.Username != "" {
+			req.SetBasicAuth(g.Username, g.Token)
+		} else if g.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+g.Token)
+		}
+	})
+	return body, err
+}
@@ -0,0 +1,252 @@
+package bitbucket_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gotgenes/getignore/pkg/bitbucket"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx               context.Context
+		server            *ghttp.Server
+		getter            bitbucket.Getter
+		expectedUserAgent = []string{fmt.Sprintf("getignore/%s", getignore.Version)}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGetter", func() {
+		It("should default to Bitbucket Cloud", func() {
+			getter, err := bitbucket.NewGetter(bitbucket.WithOwner("acme"), bitbucket.WithRepository("templates"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getter.BaseURL).Should(Equal("https://api.bitbucket.org"))
+		})
+
+		It("should require a base URL for Bitbucket Data Center", func() {
+			_, err := bitbucket.NewGetter(bitbucket.WithDataCenter(true), bitbucket.WithOwner("ACME"), bitbucket.WithRepository("templates"))
+			Expect(err).Should(MatchError("a Bitbucket Data Center base URL is required"))
+		})
+
+		It("should require a repository", func() {
+			_, err := bitbucket.NewGetter(bitbucket.WithOwner("acme"))
+			Expect(err).Should(MatchError("a Bitbucket workspace or project key, and repository, are required"))
+		})
+	})
+
+	Context("on Bitbucket Cloud", func() {
+		const srcPath = "/2.0/repositories/acme/templates/src/main/"
+
+		BeforeEach(func() {
+			getter, _ = bitbucket.NewGetter(
+				bitbucket.WithBaseURL(server.URL()),
+				bitbucket.WithOwner("acme"),
+				bitbucket.WithRepository("templates"),
+				bitbucket.WithUsername("jdoe"),
+				bitbucket.WithToken("app-password"),
+			)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/2.0/repositories/acme/templates"),
+					ghttp.VerifyHeader(http.Header{
+						"User-Agent": expectedUserAgent,
+					}),
+					ghttp.VerifyBasicAuth("jdoe", "app-password"),
+					ghttp.RespondWith(http.StatusOK, `{"slug": "templates", "mainbranch": {"type": "branch", "name": "main"}}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", srcPath, "max_depth=10&pagelen=100"),
+					ghttp.VerifyBasicAuth("jdoe", "app-password"),
+					func(w http.ResponseWriter, r *http.Request) {
+						fmt.Fprintf(w, `{
+  "pagelen": 100,
+  "page": 1,
+  "values": [
+	{"path": "Global", "type": "commit_directory"},
+	{"path": "README.md", "type": "commit_file", "size": 103},
+	{"path": "Global/Anjuta.gitignore", "type": "commit_file", "size": 78}
+  ],
+  "next": "%s%s?max_depth=10&pagelen=100&page=2"
+}`, server.URL(), srcPath)
+					},
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", srcPath, "max_depth=10&pagelen=100&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+  "pagelen": 100,
+  "page": 2,
+  "values": [
+	{"path": "Go.gitignore", "type": "commit_file", "size": 269}
+  ]
+}`),
+				),
+			)
+		})
+
+		It("should list the files with the suffix on the main branch from every page", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Global/Anjuta.gitignore", Size: 78},
+				{Path: "Go.gitignore", Size: 269},
+			}))
+		})
+
+		It("should get the contents of the files in the order of the names", func() {
+			server.RouteToHandler("GET", "/2.0/repositories/acme/templates/src/main/Go.gitignore", ghttp.CombineHandlers(
+				ghttp.VerifyBasicAuth("jdoe", "app-password"),
+				ghttp.RespondWith(http.StatusOK, "*.o\n*.a\n*.so\n"),
+			))
+			server.RouteToHandler("GET", "/2.0/repositories/acme/templates/src/main/Global/Anjuta.gitignore", ghttp.RespondWith(http.StatusOK, "/.anjuta/\n"))
+			contents, err := getter.Get(ctx, []string{"Go", "anjuta"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n*.a\n*.so\n"},
+				{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+			}))
+		})
+
+		It("should report files that fail to download", func() {
+			server.RouteToHandler("GET", "/2.0/repositories/acme/templates/src/main/Go.gitignore", ghttp.RespondWith(http.StatusInternalServerError, ""))
+			_, err := getter.Get(ctx, []string{"Go"})
+			Expect(err).Should(MatchError("error getting files from acme/templates at the default branch: failed to get the following files: Go.gitignore\nGo.gitignore: failed to download\n"))
+		})
+	})
+
+	Context("on Bitbucket Cloud with files deeper than listed at once", func() {
+		const srcPath = "/2.0/repositories/acme/templates/src/v2/"
+
+		BeforeEach(func() {
+			getter, _ = bitbucket.NewGetter(
+				bitbucket.WithBaseURL(server.URL()),
+				bitbucket.WithOwner("acme"),
+				bitbucket.WithRepository("templates"),
+				bitbucket.WithRef("v2"),
+			)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", srcPath, "max_depth=10&pagelen=100"),
+					ghttp.RespondWith(http.StatusOK, `{
+  "values": [
+	{"path": "Go.gitignore", "type": "commit_file", "size": 269},
+	{"path": "a", "type": "commit_directory"},
+	{"path": "a/b c", "type": "commit_directory"}
+  ]
+}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", srcPath+"a/b c/", "max_depth=10&pagelen=100"),
+					ghttp.RespondWith(http.StatusOK, `{
+  "values": [
+	{"path": "a/b c/Deep.gitignore", "type": "commit_file", "size": 5}
+  ]
+}`),
+				),
+			)
+		})
+
+		It("should list the directories listed without their contents in turn", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Go.gitignore", Size: 269},
+				{Path: "a/b c/Deep.gitignore", Size: 5},
+			}))
+			Expect(server.ReceivedRequests()).Should(HaveLen(2))
+		})
+	})
+
+	Context("on Bitbucket Data Center", func() {
+		const filesPath = "/rest/api/1.0/projects/ACME/repos/templates/files"
+
+		BeforeEach(func() {
+			getter, _ = bitbucket.NewGetter(
+				bitbucket.WithBaseURL(server.URL()+"/"),
+				bitbucket.WithDataCenter(true),
+				bitbucket.WithOwner("ACME"),
+				bitbucket.WithRepository("templates"),
+				bitbucket.WithRef("release/v2"),
+				bitbucket.WithToken("http-access-token"),
+			)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", filesPath, "at=release%2Fv2&limit=100&start=0"),
+					ghttp.VerifyHeader(http.Header{
+						"User-Agent":    expectedUserAgent,
+						"Authorization": []string{"Bearer http-access-token"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{
+  "size": 2,
+  "limit": 2,
+  "isLastPage": false,
+  "values": ["README.md", "Global/Anjuta.gitignore"],
+  "start": 0,
+  "nextPageStart": 2
+}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", filesPath, "at=release%2Fv2&limit=100&start=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+  "size": 1,
+  "limit": 2,
+  "isLastPage": true,
+  "values": ["Go.gitignore"],
+  "start": 2
+}`),
+				),
+			)
+		})
+
+		It("should list the files with the suffix from every page", func() {
+			files, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{"Global/Anjuta.gitignore", "Go.gitignore"}))
+		})
+
+		It("should get the contents of the files at the ref", func() {
+			server.RouteToHandler("GET", "/rest/api/1.0/projects/ACME/repos/templates/raw/Global/Anjuta.gitignore", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/rest/api/1.0/projects/ACME/repos/templates/raw/Global/Anjuta.gitignore", "at=release%2Fv2"),
+				ghttp.VerifyHeader(http.Header{
+					"Authorization": []string{"Bearer http-access-token"},
+				}),
+				ghttp.RespondWith(http.StatusOK, "/.anjuta/\n"),
+			))
+			contents, err := getter.Get(ctx, []string{"Global/*"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+			}))
+		})
+	})
+
+	Context("when the server errors", func() {
+		BeforeEach(func() {
+			getter, _ = bitbucket.NewGetter(
+				bitbucket.WithBaseURL(server.URL()),
+				bitbucket.WithDataCenter(true),
+				bitbucket.WithOwner("ACME"),
+				bitbucket.WithRepository("templates"),
+			)
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"errors": [{"message": "Repository ACME/templates does not exist."}]}`))
+		})
+
+		It("should return an error", func() {
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(HavePrefix("error listing contents of ACME/templates at the default branch: unable to get file information: GET ")))
+			Expect(err).Should(MatchError(HaveSuffix(": 404 Not Found")))
+		})
+	})
+})
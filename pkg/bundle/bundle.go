@@ -0,0 +1,207 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// Manifest describes the snapshot of a source held by a bundle
+type Manifest struct {
+	// Format is the version of the format of the bundle
+	Format int `json:"format"`
+	// Source is the source the files were got from, e.g.,
+	// github/gitignore@main
+	Source string `json:"source"`
+	// Created is when the bundle was created
+	Created time.Time `json:"created"`
+	// Generator names the version of getignore that created the bundle
+	Generator string       `json:"generator"`
+	Files     []BundleFile `json:"files"`
+}
+
+// BundleFile describes a file held by a bundle, with checksums of its
+// contents
+type BundleFile struct {
+	Path string `json:"path"`
+	// SHA is the Git blob SHA of the contents of the file
+	SHA string `json:"sha"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the contents of the file
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// Bundle is a snapshot of the files of a source, read from a bundle file
+type Bundle struct {
+	Manifest Manifest
+	// contents maps the paths of the files to their contents
+	contents map[string]string
+}
+
+// NewBundle returns a bundle of the contents got from the source at the time
+func NewBundle(source string, created time.Time, contents []getignore.NamedContents) Bundle {
+	b := Bundle{
+		Manifest: Manifest{
+			Format:    FormatVersion,
+			Source:    source,
+			Created:   created.UTC(),
+			Generator: fmt.Sprintf("getignore/%s", getignore.Version),
+		},
+		contents: make(map[string]string, len(contents)),
+	}
+	for _, nc := range contents {
+		b.Manifest.Files = append(b.Manifest.Files, BundleFile{
+			Path:   nc.Name,
+			SHA:    getignore.BlobSHA(nc.Contents),
+			SHA256: sha256Hex(nc.Contents),
+			Size:   len(nc.Contents),
+		})
+		b.contents[nc.Name] = nc.Contents
+	}
+	return b
+}
+
+// Create gets all the files from the source and returns a bundle of them
+func Create(ctx context.Context, source getignore.Source, sourceName string, created time.Time) (Bundle, error) {
+	entries, err := source.ListEntries(ctx)
+	if err != nil {
+		return Bundle{}, err
+	}
+	paths := getignore.EntryPaths(entries)
+	if len(paths) == 0 {
+		return Bundle{}, fmt.Errorf("no files to bundle from %s", sourceName)
+	}
+	contents, err := source.Get(ctx, paths)
+	if err != nil {
+		return Bundle{}, err
+	}
+	return NewBundle(sourceName, created, contents), nil
+}
+
+// Contents returns the contents of the file at the path, and whether the
+// bundle holds it
+func (b Bundle) Contents(filePath string) (string, bool) {
+	contents, ok := b.contents[filePath]
+	return contents, ok
+}
+
+// Write writes the bundle as a gzipped tar archive of its manifest, followed
+// by its files within the files directory
+func (b Bundle) Write(w io.Writer) error {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, manifestName, string(manifest)+"\n", b.Manifest.Created); err != nil {
+		return err
+	}
+	for _, file := range b.Manifest.Files {
+		if err := writeTarFile(tarWriter, filesDir+file.Path, b.contents[file.Path], b.Manifest.Created); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, contents string, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(contents)),
+		ModTime: modTime,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.WriteString(tarWriter, contents)
+	return err
+}
+
+// Read reads a bundle written by Write, checking each file against the
+// checksums in the manifest
+func Read(r io.Reader) (Bundle, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("invalid bundle: %w", err)
+	}
+	defer gzipReader.Close()
+	var (
+		manifestData []byte
+		files        = make(map[string]string)
+	)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Bundle{}, fmt.Errorf("invalid bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("invalid bundle: %w", err)
+		}
+		if header.Name == manifestName {
+			manifestData = data
+		} else if strings.HasPrefix(header.Name, filesDir) {
+			files[strings.TrimPrefix(header.Name, filesDir)] = string(data)
+		}
+	}
+	if manifestData == nil {
+		return Bundle{}, fmt.Errorf("invalid bundle: missing %s", manifestName)
+	}
+	b := Bundle{contents: make(map[string]string)}
+	if err := json.Unmarshal(manifestData, &b.Manifest); err != nil {
+		return Bundle{}, fmt.Errorf("invalid bundle: %s: %w", manifestName, err)
+	}
+	if b.Manifest.Format != FormatVersion {
+		return Bundle{}, fmt.Errorf("unsupported bundle format %d; expected %d", b.Manifest.Format, FormatVersion)
+	}
+	for _, file := range b.Manifest.Files {
+		contents, ok := files[file.Path]
+		if !ok || path.Clean(file.Path) != file.Path {
+			return Bundle{}, fmt.Errorf("invalid bundle: missing %s", file.Path)
+		}
+		if err := file.verify(contents); err != nil {
+			return Bundle{}, fmt.Errorf("invalid bundle: %s: %w", file.Path, err)
+		}
+		b.contents[file.Path] = contents
+	}
+	return b, nil
+}
+
+// verify checks the contents against the checksums of the file
+func (f BundleFile) verify(contents string) error {
+	if sum := sha256Hex(contents); sum != f.SHA256 {
+		return fmt.Errorf("checksum mismatch: expected SHA-256 %s, got %s", f.SHA256, sum)
+	}
+	if sha := getignore.BlobSHA(contents); sha != f.SHA {
+		return fmt.Errorf("checksum mismatch: expected SHA %s, got %s", f.SHA, sha)
+	}
+	return nil
+}
+
+func sha256Hex(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,173 @@
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// mapSource gets files by their exact paths from a map of the paths to their
+// contents
+type mapSource struct {
+	files map[string]string
+	err   error
+}
+
+func (s mapSource) List(ctx context.Context) ([]string, error) {
+	entries, err := s.ListEntries(ctx)
+	return getignore.EntryPaths(entries), err
+}
+
+func (s mapSource) ListEntries(context.Context) ([]getignore.FileEntry, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var entries []getignore.FileEntry
+	for p, contents := range s.files {
+		entries = append(entries, getignore.FileEntry{Path: p, SHA: getignore.BlobSHA(contents), Size: len(contents)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+func (s mapSource) Get(_ context.Context, names []string) ([]getignore.NamedContents, error) {
+	var contents []getignore.NamedContents
+	for _, name := range names {
+		contents = append(contents, getignore.NamedContents{Name: name, Contents: s.files[name]})
+	}
+	return contents, nil
+}
+
+func sha256Hex(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeArchive writes a gzipped tar archive of the files
+func writeArchive(files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name]))})).Should(Succeed())
+		_, err := tarWriter.Write([]byte(files[name]))
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+	Expect(tarWriter.Close()).Should(Succeed())
+	Expect(gzipWriter.Close()).Should(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("Bundle", func() {
+	var (
+		ctx     context.Context
+		source  mapSource
+		created time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		source = mapSource{files: map[string]string{
+			"Go.gitignore":         "*.o\n",
+			"Global/Vim.gitignore": "*.swp\n",
+			"README.md":            "# Templates\n",
+		}}
+		created = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	})
+
+	Describe("Create", func() {
+		It("should snapshot every file of the source with its checksums", func() {
+			b, err := bundle.Create(ctx, source, "github/gitignore@main", created)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b.Manifest).Should(Equal(bundle.Manifest{
+				Format:    bundle.FormatVersion,
+				Source:    "github/gitignore@main",
+				Created:   created,
+				Generator: fmt.Sprintf("getignore/%s", getignore.Version),
+				Files: []bundle.BundleFile{
+					{Path: "Global/Vim.gitignore", SHA: getignore.BlobSHA("*.swp\n"), SHA256: sha256Hex("*.swp\n"), Size: 6},
+					{Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n"), SHA256: sha256Hex("*.o\n"), Size: 4},
+					{Path: "README.md", SHA: getignore.BlobSHA("# Templates\n"), SHA256: sha256Hex("# Templates\n"), Size: 12},
+				},
+			}))
+			contents, ok := b.Contents("Go.gitignore")
+			Expect(ok).Should(BeTrue())
+			Expect(contents).Should(Equal("*.o\n"))
+		})
+
+		It("should fail when the source fails", func() {
+			source.err = errors.New("no network")
+			_, err := bundle.Create(ctx, source, "github/gitignore@main", created)
+			Expect(err).Should(MatchError("no network"))
+		})
+
+		It("should fail when the source has no files", func() {
+			source.files = nil
+			_, err := bundle.Create(ctx, source, "github/gitignore@main", created)
+			Expect(err).Should(MatchError("no files to bundle from github/gitignore@main"))
+		})
+	})
+
+	Describe("Read", func() {
+		It("should read a bundle written by Write", func() {
+			b, err := bundle.Create(ctx, source, "github/gitignore@main", created)
+			Expect(err).ShouldNot(HaveOccurred())
+			var buf bytes.Buffer
+			Expect(b.Write(&buf)).Should(Succeed())
+			read, err := bundle.Read(&buf)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(read).Should(Equal(b))
+		})
+
+		It("should fail for files not matching their checksums", func() {
+			archive := writeArchive(map[string]string{
+				"bundle.json":        fmt.Sprintf(`{"format": 1, "files": [{"path": "Go.gitignore", "sha": "%s", "sha256": "0000", "size": 4}]}`, getignore.BlobSHA("*.o\n")),
+				"files/Go.gitignore": "*.o\n",
+			})
+			_, err := bundle.Read(bytes.NewReader(archive))
+			Expect(err).Should(MatchError(MatchRegexp(`^invalid bundle: Go\.gitignore: checksum mismatch: expected SHA-256 0000, got [0-9a-f]{64}$`)))
+		})
+
+		It("should fail for files missing from the archive", func() {
+			archive := writeArchive(map[string]string{
+				"bundle.json": `{"format": 1, "files": [{"path": "Go.gitignore"}]}`,
+			})
+			_, err := bundle.Read(bytes.NewReader(archive))
+			Expect(err).Should(MatchError("invalid bundle: missing Go.gitignore"))
+		})
+
+		It("should fail without a manifest", func() {
+			archive := writeArchive(map[string]string{"files/Go.gitignore": "*.o\n"})
+			_, err := bundle.Read(bytes.NewReader(archive))
+			Expect(err).Should(MatchError("invalid bundle: missing bundle.json"))
+		})
+
+		It("should fail for other formats", func() {
+			archive := writeArchive(map[string]string{"bundle.json": `{"format": 2}`})
+			_, err := bundle.Read(bytes.NewReader(archive))
+			Expect(err).Should(MatchError("unsupported bundle format 2; expected 1"))
+		})
+
+		It("should fail for files that aren't gzipped", func() {
+			_, err := bundle.Read(bytes.NewReader([]byte("*.o\n")))
+			Expect(err).Should(MatchError(HavePrefix("invalid bundle: ")))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package bundle
+
+const (
+	Suffix = ".gitignore"
+	// FormatVersion is the version of the format of bundles written
+	FormatVersion = 1
+
+	// manifestName is the name of the manifest within a bundle
+	manifestName = "bundle.json"
+	// filesDir is the directory of the files within a bundle
+	filesDir = "files/"
+)
@@ -0,0 +1,167 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// Getter lists and gets files from a bundle written by Bundle.Write, without
+// needing the network
+type Getter struct {
+	// fsys holds the bundle file, if not the local file system
+	fsys fs.FS
+	// Path is the path of the bundle file
+	Path string
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	fsys     fs.FS
+	path     string
+	resolver getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		resolver: getignore.ResolverSettings{Suffix: Suffix},
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.path == "" {
+		return Getter{}, errors.New("a bundle path is required")
+	}
+	return Getter{
+		fsys:             params.fsys,
+		Path:             params.path,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithPath sets the path of the bundle file for the Getter
+func WithPath(path string) GetterOption {
+	return func(p *getterParams) {
+		p.path = path
+	}
+}
+
+// WithFS sets the file system holding the bundle file, e.g., one embedded in
+// the binary, in place of the local file system
+func WithFS(fsys fs.FS) GetterOption {
+	return func(p *getterParams) {
+		p.fsys = fsys
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix.
+func (g Getter) ListEntries(context.Context) ([]getignore.FileEntry, error) {
+	b, err := g.readBundle()
+	if err != nil {
+		return nil, fmt.Errorf("error listing contents of %s: %w", g.Path, err)
+	}
+	var entries []getignore.FileEntry
+	for _, file := range b.Manifest.Files {
+		if strings.HasSuffix(file.Path, g.Suffix) {
+			entries = append(entries, getignore.FileEntry{Path: file.Path, SHA: file.SHA, Size: file.Size})
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files in the bundle with the given
+// names
+func (g Getter) Get(_ context.Context, names []string) ([]getignore.NamedContents, error) {
+	b, err := g.readBundle()
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	paths := make([]string, len(b.Manifest.Files))
+	for i, file := range b.Manifest.Files {
+		paths[i] = file.Path
+	}
+	names, failedFiles := g.Resolver().Resolve(names, paths)
+	var namedContents []getignore.NamedContents
+	for _, name := range names {
+		contents, _ := b.Contents(name)
+		namedContents = append(namedContents, getignore.NamedContents{Name: name, Contents: contents})
+	}
+	if failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+// GetBlob gets the contents of the file in the bundle with the SHA
+func (g Getter) GetBlob(_ context.Context, sha string) (string, error) {
+	b, err := g.readBundle()
+	if err != nil {
+		return "", g.newGetError(err)
+	}
+	for _, file := range b.Manifest.Files {
+		if file.SHA == sha {
+			contents, _ := b.Contents(file.Path)
+			return contents, nil
+		}
+	}
+	return "", g.newGetError(getignore.FailedFile{
+		Name:    sha,
+		Message: "not present in bundle",
+	})
+}
+
+// Manifest returns the manifest of the bundle
+func (g Getter) Manifest() (Manifest, error) {
+	b, err := g.readBundle()
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error reading %s: %w", g.Path, err)
+	}
+	return b.Manifest, nil
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s: %w", g.Path, err)
+}
+
+// readBundle reads the bundle file
+func (g Getter) readBundle() (Bundle, error) {
+	var (
+		f   fs.File
+		err error
+	)
+	if g.fsys != nil {
+		f, err = g.fsys.Open(g.Path)
+	} else {
+		f, err = os.Open(g.Path)
+	}
+	if err != nil {
+		return Bundle{}, err
+	}
+	defer f.Close()
+	return Read(f)
+}
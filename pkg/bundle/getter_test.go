@@ -0,0 +1,116 @@
+package bundle_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+	"time"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx        context.Context
+		dir        string
+		bundlePath string
+		getter     bundle.Getter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		dir, err = os.MkdirTemp("", "getignore-bundle")
+		Expect(err).ShouldNot(HaveOccurred())
+		bundlePath = filepath.Join(dir, "templates.tar.gz")
+		b := bundle.NewBundle("github/gitignore@main", time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), []getignore.NamedContents{
+			{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+			{Name: "Go.gitignore", Contents: "*.o\n"},
+			{Name: "README.md", Contents: "# Templates\n"},
+		})
+		f, err := os.Create(bundlePath)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Write(f)).Should(Succeed())
+		Expect(f.Close()).Should(Succeed())
+		getter, _ = bundle.NewGetter(bundle.WithPath(bundlePath))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("NewGetter", func() {
+		It("should require a bundle path", func() {
+			_, err := bundle.NewGetter()
+			Expect(err).Should(MatchError("a bundle path is required"))
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the files with the suffix", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Global/Vim.gitignore", SHA: getignore.BlobSHA("*.swp\n"), Size: 6},
+				{Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n"), Size: 4},
+			}))
+		})
+
+		It("should list the files of a bundle in the file system", func() {
+			data, err := os.ReadFile(bundlePath)
+			Expect(err).ShouldNot(HaveOccurred())
+			getter, _ = bundle.NewGetter(
+				bundle.WithFS(fstest.MapFS{"snapshot/gitignore.tar.gz": {Data: data}}),
+				bundle.WithPath("snapshot/gitignore.tar.gz"),
+			)
+			Expect(getter.List(ctx)).Should(Equal([]string{"Global/Vim.gitignore", "Go.gitignore"}))
+		})
+
+		It("should return an error for a missing bundle", func() {
+			getter.Path = bundlePath + ".missing"
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(HavePrefix("error listing contents of " + bundlePath + ".missing: ")))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get the files in the order of the names", func() {
+			contents, err := getter.Get(ctx, []string{"vim", "Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "Go.gitignore", Contents: "*.o\n"},
+			}))
+		})
+
+		It("should report names not present in the bundle", func() {
+			contents, err := getter.Get(ctx, []string{"Go", "Rust"})
+			Expect(err).Should(MatchError("error getting files from " + bundlePath + ": failed to get the following files: Rust\nRust: not present in file tree\n"))
+			Expect(contents).Should(HaveLen(1))
+		})
+	})
+
+	Describe("GetBlob", func() {
+		It("should get the file with the SHA", func() {
+			Expect(getter.GetBlob(ctx, getignore.BlobSHA("*.o\n"))).Should(Equal("*.o\n"))
+		})
+
+		It("should fail for SHAs not present in the bundle", func() {
+			_, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+			Expect(err).Should(MatchError("error getting files from " + bundlePath + ": failed to get 66fd13c903cac02eb9657cd53fb227823484401d: not present in bundle"))
+		})
+	})
+
+	Describe("Manifest", func() {
+		It("should return the manifest of the bundle", func() {
+			manifest, err := getter.Manifest()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(manifest.Source).Should(Equal("github/gitignore@main"))
+			Expect(manifest.Files).Should(HaveLen(3))
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/gotgenes/getignore/pkg/getignore"
+
+// prefixSource formats and parses sources giving the path of a bundle
+var prefixSource = getignore.PrefixSource{Prefix: "bundle+", Location: "the path of a bundle"}
+
+// FormatSource returns the source for the bundle at the path, e.g.,
+// bundle+/opt/getignore/templates.tar.gz, for recording in banners and lock
+// files
+func FormatSource(bundlePath string) string {
+	return prefixSource.Format(bundlePath)
+}
+
+// IsSource reports whether the source is a bundle, as given by FormatSource
+func IsSource(source string) bool {
+	return prefixSource.Is(source)
+}
+
+// ParseSource parses a source given by FormatSource into the path of the
+// bundle
+func ParseSource(source string) (string, error) {
+	return prefixSource.Parse(source)
+}
@@ -0,0 +1,70 @@
+package embedded
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"log"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/gitremote"
+)
+
+// SourceName is the source recorded in banners for files from the embedded
+// snapshot
+const SourceName = "embedded"
+
+// snapshotPath is the path of the snapshot, a bundle written by
+// bundle.Bundle.Write, within snapshotFS
+const snapshotPath = "snapshot/gitignore.tar.gz"
+
+// snapshotFS holds the snapshot, written by `make embedded-snapshot`
+//
+//go:embed snapshot
+var snapshotFS embed.FS
+
+// ErrNoSnapshot is returned for builds of getignore without a snapshot
+var ErrNoSnapshot = errors.New("this build of getignore has no embedded snapshot of github/gitignore")
+
+// Available reports whether this build of getignore has a snapshot
+func Available() bool {
+	_, err := fs.Stat(snapshotFS, snapshotPath)
+	return err == nil
+}
+
+// NewGetter returns a getter for the files of the snapshot, with the options
+// given besides its path
+func NewGetter(options ...bundle.GetterOption) (bundle.Getter, error) {
+	if !Available() {
+		return bundle.Getter{}, ErrNoSnapshot
+	}
+	options = append([]bundle.GetterOption{bundle.WithFS(snapshotFS), bundle.WithPath(snapshotPath)}, options...)
+	return bundle.NewGetter(options...)
+}
+
+// Manifest returns the manifest of the snapshot, recording the commit of
+// github/gitignore it was created from, and when
+func Manifest() (bundle.Manifest, error) {
+	getter, err := NewGetter()
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	return getter.Manifest()
+}
+
+// Warn warns that the files of the getter, for the snapshot or a bundle like
+// it, may be out of date, giving the commit of github/gitignore the snapshot
+// was created at, and when
+func Warn(getter bundle.Getter) error {
+	manifest, err := getter.Manifest()
+	if err != nil {
+		return err
+	}
+	snapshot := "from " + manifest.Source
+	if _, ref, err := gitremote.ParseSource(manifest.Source); err == nil && ref != "" {
+		snapshot = "at commit " + ref
+	}
+	log.Printf("Warning: using the snapshot of github/gitignore embedded in getignore, %s, as of %s; files may be out of date",
+		snapshot, manifest.Created.Format("2006-01-02"))
+	return nil
+}
@@ -0,0 +1,98 @@
+package embedded_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"testing/fstest"
+	"time"
+
+	"github.com/gotgenes/getignore/pkg/bundle"
+	"github.com/gotgenes/getignore/pkg/embedded"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitremote"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newBundleGetter returns a getter for a bundle of the files created from the
+// source, read through bundle.WithFS as the snapshot is
+func newBundleGetter(source string) bundle.Getter {
+	b := bundle.NewBundle(source, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), []getignore.NamedContents{
+		{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+		{Name: "Go.gitignore", Contents: "*.o\n"},
+	})
+	var buf bytes.Buffer
+	Expect(b.Write(&buf)).Should(Succeed())
+	getter, err := bundle.NewGetter(
+		bundle.WithFS(fstest.MapFS{"snapshot/gitignore.tar.gz": {Data: buf.Bytes()}}),
+		bundle.WithPath("snapshot/gitignore.tar.gz"),
+	)
+	Expect(err).ShouldNot(HaveOccurred())
+	return getter
+}
+
+// captureLog sends the log to the buffer until the end of each test
+func captureLog(logBuffer *bytes.Buffer) {
+	BeforeEach(func() {
+		logBuffer.Reset()
+		log.SetOutput(logBuffer)
+		log.SetFlags(0)
+	})
+
+	AfterEach(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	})
+}
+
+var _ = Describe("Snapshot", func() {
+	It("should be embedded", func() {
+		Expect(embedded.Available()).Should(BeTrue(), "run make embedded-snapshot to create the snapshot")
+	})
+
+	It("should be a snapshot of a commit of github/gitignore", func() {
+		manifest, err := embedded.Manifest()
+		Expect(err).ShouldNot(HaveOccurred())
+		remote, ref, err := gitremote.ParseSource(manifest.Source)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remote).Should(Equal("https://github.com/github/gitignore.git"))
+		Expect(ref).Should(MatchRegexp("^[0-9a-f]{40}$"))
+	})
+
+	It("should get files from the snapshot", func() {
+		getter, err := embedded.NewGetter()
+		Expect(err).ShouldNot(HaveOccurred())
+		contents, err := getter.Get(context.Background(), []string{"Go"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(contents).Should(HaveLen(1))
+		Expect(contents[0].Name).Should(Equal("Go.gitignore"))
+		Expect(contents[0].Contents).ShouldNot(BeEmpty())
+	})
+})
+
+var _ = Describe("Warn", func() {
+	var logBuffer bytes.Buffer
+
+	captureLog(&logBuffer)
+
+	It("should warn with the commit and date of the snapshot", func() {
+		getter := newBundleGetter("git+https://github.com/github/gitignore.git#8f2c1e7d9a")
+		Expect(embedded.Warn(getter)).Should(Succeed())
+		Expect(logBuffer.String()).Should(Equal("Warning: using the snapshot of github/gitignore embedded in getignore, at commit 8f2c1e7d9a, as of 2026-10-19; files may be out of date\n"))
+	})
+
+	It("should warn with the source of a snapshot without a commit", func() {
+		getter := newBundleGetter("github/gitignore@main")
+		Expect(embedded.Warn(getter)).Should(Succeed())
+		Expect(logBuffer.String()).Should(Equal("Warning: using the snapshot of github/gitignore embedded in getignore, from github/gitignore@main, as of 2026-10-19; files may be out of date\n"))
+	})
+
+	It("should return an error for a missing snapshot", func() {
+		getter, err := bundle.NewGetter(bundle.WithFS(fstest.MapFS{}), bundle.WithPath("snapshot/gitignore.tar.gz"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(embedded.Warn(getter)).ShouldNot(Succeed())
+		Expect(logBuffer.String()).Should(BeEmpty())
+	})
+})
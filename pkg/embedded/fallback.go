@@ -0,0 +1,74 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// FallbackSource gets files from the snapshot when its source, of which the
+// snapshot is a copy, can't be reached over the network. Files got from the
+// snapshot are attributed to it, so lock files record where they came from.
+type FallbackSource struct {
+	getignore.Source
+	// NewFallback returns the getter for the snapshot, when first needed
+	NewFallback func() (getignore.Source, error)
+}
+
+func (s FallbackSource) List(ctx context.Context) ([]string, error) {
+	entries, err := s.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+func (s FallbackSource) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	entries, err := s.Source.ListEntries(ctx)
+	if !isNetworkError(err) {
+		return entries, err
+	}
+	fallback, fallbackErr := s.fallback(err)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	return fallback.ListEntries(ctx)
+}
+
+func (s FallbackSource) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	contents, err := s.Source.Get(ctx, names)
+	if !isNetworkError(err) {
+		return contents, err
+	}
+	fallback, fallbackErr := s.fallback(err)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	contents, err = fallback.Get(ctx, names)
+	for i := range contents {
+		contents[i].Source = SourceName
+	}
+	return contents, err
+}
+
+// Unwrap returns the source the files are got from when it can be reached
+func (s FallbackSource) Unwrap() getignore.Getter {
+	return s.Source
+}
+
+// fallback returns the source to fall back to, reporting the error getting
+// files from the source
+func (s FallbackSource) fallback(err error) (getignore.Source, error) {
+	log.Printf("Warning: %v", err)
+	return s.NewFallback()
+}
+
+// isNetworkError reports whether the error is due to failing to reach a host,
+// e.g., when offline, rather than a response from it
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,106 @@
+package embedded_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/gotgenes/getignore/pkg/embedded"
+	"github.com/gotgenes/getignore/pkg/getignore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// failingSource fails to list and get files with its error
+type failingSource struct {
+	err error
+}
+
+func (s failingSource) List(context.Context) ([]string, error) {
+	return nil, s.err
+}
+
+func (s failingSource) ListEntries(context.Context) ([]getignore.FileEntry, error) {
+	return nil, s.err
+}
+
+func (s failingSource) Get(context.Context, []string) ([]getignore.NamedContents, error) {
+	return nil, s.err
+}
+
+var _ = Describe("FallbackSource", func() {
+	var (
+		ctx         context.Context
+		logBuffer   bytes.Buffer
+		fallbacks   int
+		fallbackErr error
+		networkErr  = fmt.Errorf("error getting files from github/gitignore: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+		newFallback = func() (getignore.Source, error) {
+			fallbacks++
+			if fallbackErr != nil {
+				return nil, fallbackErr
+			}
+			return newBundleGetter("github/gitignore@main"), nil
+		}
+	)
+
+	captureLog(&logBuffer)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fallbacks = 0
+		fallbackErr = nil
+	})
+
+	Context("when the source can't be reached", func() {
+		var source embedded.FallbackSource
+
+		BeforeEach(func() {
+			source = embedded.FallbackSource{Source: failingSource{err: networkErr}, NewFallback: newFallback}
+		})
+
+		It("should get the files from the snapshot, attributed to it, with a warning", func() {
+			contents, err := source.Get(ctx, []string{"Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n", Source: embedded.SourceName},
+			}))
+			Expect(logBuffer.String()).Should(Equal("Warning: error getting files from github/gitignore: dial tcp: connection refused\n"))
+		})
+
+		It("should list the files of the snapshot", func() {
+			Expect(source.List(ctx)).Should(Equal([]string{"Global/Vim.gitignore", "Go.gitignore"}))
+			Expect(logBuffer.String()).Should(HavePrefix("Warning: "))
+		})
+
+		It("should return the error of the source when the snapshot is unavailable", func() {
+			fallbackErr = embedded.ErrNoSnapshot
+			_, err := source.Get(ctx, []string{"Go"})
+			Expect(err).Should(MatchError(networkErr))
+		})
+	})
+
+	Context("when the source fails otherwise", func() {
+		var source embedded.FallbackSource
+
+		BeforeEach(func() {
+			source = embedded.FallbackSource{Source: failingSource{err: errors.New("GET https://api.github.com/: 404 Not Found")}, NewFallback: newFallback}
+		})
+
+		It("should return the error without falling back", func() {
+			_, err := source.Get(ctx, []string{"Go"})
+			Expect(err).Should(MatchError("GET https://api.github.com/: 404 Not Found"))
+			_, err = source.ListEntries(ctx)
+			Expect(err).Should(MatchError("GET https://api.github.com/: 404 Not Found"))
+			Expect(fallbacks).Should(BeZero())
+			Expect(logBuffer.String()).Should(BeEmpty())
+		})
+	})
+
+	It("should unwrap to its source", func() {
+		source := failingSource{err: networkErr}
+		Expect(embedded.FallbackSource{Source: source}.Unwrap()).Should(Equal(source))
+	})
+})
@@ -0,0 +1,75 @@
+package getignore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to the path of a file to name its backup
+const BackupSuffix = ".bak"
+
+// defaultFileMode is the mode of files written where none existed before
+const defaultFileMode fs.FileMode = 0o644
+
+// WriteFileAtomic writes a file by passing a temporary file in the same
+// directory to write, then renaming it to the path, so that the file is
+// either entirely replaced or left untouched. The file keeps the mode of any
+// file it replaces. If backup is true, the replaced file is first copied to
+// the path with BackupSuffix appended.
+func WriteFileAtomic(path string, write func(io.Writer) error, backup bool) (err error) {
+	mode := defaultFileMode
+	info, err := os.Stat(path)
+	exists := err == nil
+	if exists {
+		mode = info.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tempFile, err := os.CreateTemp(dir, "."+base+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+		}
+	}()
+	if err = write(tempFile); err != nil {
+		return err
+	}
+	if err = tempFile.Chmod(mode); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	if err = tempFile.Sync(); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	if err = tempFile.Close(); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	if backup && exists {
+		if err = copyFile(path, path+BackupSuffix, mode); err != nil {
+			return fmt.Errorf("unable to back up %s: %w", path, err)
+		}
+	}
+	if err = os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func copyFile(src string, dst string, mode fs.FileMode) error {
+	contents, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, contents, mode)
+}
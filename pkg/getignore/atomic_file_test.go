@@ -0,0 +1,79 @@
+package getignore_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("WriteFileAtomic", func() {
+	var (
+		dir  string
+		path string
+	)
+
+	writeString := func(s string) func(io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := io.WriteString(w, s)
+			return err
+		}
+	}
+
+	readFile := func(p string) string {
+		contents, err := os.ReadFile(p)
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(contents)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "getignore")
+		Expect(err).ShouldNot(HaveOccurred())
+		path = filepath.Join(dir, ".gitignore")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should create the file", func() {
+		Expect(getignore.WriteFileAtomic(path, writeString("*.o\n"), false)).Should(Succeed())
+		Expect(readFile(path)).Should(Equal("*.o\n"))
+	})
+
+	It("should replace the file, keeping its mode", func() {
+		Expect(os.WriteFile(path, []byte("old\n"), 0o600)).Should(Succeed())
+		Expect(getignore.WriteFileAtomic(path, writeString("new\n"), false)).Should(Succeed())
+		Expect(readFile(path)).Should(Equal("new\n"))
+		info, err := os.Stat(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(info.Mode().Perm()).Should(Equal(os.FileMode(0o600)))
+		Expect(path + ".bak").ShouldNot(BeAnExistingFile())
+	})
+
+	It("should back up the replaced file", func() {
+		Expect(os.WriteFile(path, []byte("old\n"), 0o644)).Should(Succeed())
+		Expect(getignore.WriteFileAtomic(path, writeString("new\n"), true)).Should(Succeed())
+		Expect(readFile(path)).Should(Equal("new\n"))
+		Expect(readFile(path + ".bak")).Should(Equal("old\n"))
+	})
+
+	It("should leave the file untouched when writing fails", func() {
+		Expect(os.WriteFile(path, []byte("old\n"), 0o644)).Should(Succeed())
+		err := getignore.WriteFileAtomic(path, func(w io.Writer) error {
+			io.WriteString(w, "partial")
+			return errors.New("download failed")
+		}, true)
+		Expect(err).Should(MatchError("download failed"))
+		Expect(readFile(path)).Should(Equal("old\n"))
+		entries, err := os.ReadDir(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entries).Should(HaveLen(1))
+	})
+})
@@ -0,0 +1,265 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Origins of the lines of a gitignore file
+const (
+	// OriginUpstream lines come from a gitignore patterns file
+	OriginUpstream = "upstream"
+	// OriginLocal lines were added by hand, or come from local files or
+	// inline patterns
+	OriginLocal = "local"
+	// OriginGenerated lines were generated by getignore, such as section
+	// headers
+	OriginGenerated = "getignore"
+)
+
+// Revision is the contents of a file as of a commit
+type Revision struct {
+	Commit   Commit
+	Contents string
+}
+
+// TemplateBlame is the commit that last changed each line of a version of a
+// gitignore patterns file
+type TemplateBlame struct {
+	Lines   []string
+	Commits []Commit
+	// OlderThan is the oldest commit searched, when the commits searched did
+	// not go back to the one creating the file; lines unchanged in all of
+	// them were last changed before it, and have no commit
+	OlderThan Commit
+}
+
+// RevisionGetter gets the contents of a file as of a commit
+type RevisionGetter func(commit Commit) (string, error)
+
+// LineBlame describes where a line of a gitignore file came from
+type LineBlame struct {
+	LineNumber int
+	Line       string
+	// Section is the name of the section containing the line, if any
+	Section string
+	Origin  string
+	// Commit last changed the line, for lines from upstream
+	Commit Commit
+	// OlderThan is the commit before which the line was last changed, for
+	// lines from upstream with no commit
+	OlderThan Commit
+}
+
+// BlameRevisions attributes each line of the first revision to the commit
+// that last changed it, given revisions of a file from newest to oldest.
+// Lines unchanged since the oldest revision are attributed to it.
+func BlameRevisions(revisions []Revision) TemplateBlame {
+	commits := make([]Commit, len(revisions))
+	contents := make(map[string]string, len(revisions))
+	for i, revision := range revisions {
+		commits[i] = revision.Commit
+		contents[revision.Commit.SHA] = revision.Contents
+	}
+	blame, _ := BlameCommits(commits, true, func(commit Commit) (string, error) {
+		return contents[commit.SHA], nil
+	})
+	return blame
+}
+
+// BlameCommits attributes each line of a file, as of the first commit, to the
+// commit that last changed it, given the commits that changed the file from
+// newest to oldest. It gets the contents as of each commit only as needed,
+// stopping once every line is attributed.
+//
+// If complete, the oldest commit created the file, and lines unchanged in
+// all the commits are attributed to it; otherwise, as when the commits were
+// limited, those lines have no commit, and the blame is OlderThan the oldest
+// commit.
+func BlameCommits(commits []Commit, complete bool, getContents RevisionGetter) (TemplateBlame, error) {
+	if len(commits) == 0 {
+		return TemplateBlame{}, nil
+	}
+	contents, err := getContents(commits[0])
+	if err != nil {
+		return TemplateBlame{}, err
+	}
+	lines := splitLines(contents)
+	blame := TemplateBlame{Lines: lines, Commits: make([]Commit, len(lines))}
+	// positions holds the index of each line not yet attributed within the
+	// revision being compared, or -1 once attributed
+	positions := make([]int, len(lines))
+	for i := range positions {
+		positions[i] = i
+	}
+	unattributed := len(lines)
+	newerLines := lines
+	for c := 0; c+1 < len(commits) && unattributed > 0; c++ {
+		olderContents, err := getContents(commits[c+1])
+		if err != nil {
+			return TemplateBlame{}, err
+		}
+		olderLines := splitLines(olderContents)
+		olderIndices := unchangedLineIndices(olderLines, newerLines)
+		for i, position := range positions {
+			if position < 0 {
+				continue
+			}
+			if olderIndex, ok := olderIndices[position]; ok {
+				positions[i] = olderIndex
+			} else {
+				blame.Commits[i] = commits[c]
+				positions[i] = -1
+				unattributed--
+			}
+		}
+		newerLines = olderLines
+	}
+	if unattributed == 0 {
+		return blame, nil
+	}
+	oldest := commits[len(commits)-1]
+	if !complete {
+		blame.OlderThan = oldest
+		return blame, nil
+	}
+	for i, position := range positions {
+		if position >= 0 {
+			blame.Commits[i] = oldest
+		}
+	}
+	return blame, nil
+}
+
+// unchangedLineIndices maps the index of each line of the new lines that is
+// unchanged from the old lines to its index in the old lines
+func unchangedLineIndices(oldLines []string, newLines []string) map[int]int {
+	indices := make(map[int]int)
+	oldIndex, newIndex := 0, 0
+	for _, op := range diffLines(oldLines, newLines) {
+		switch op.kind {
+		case ' ':
+			indices[newIndex] = oldIndex
+			oldIndex++
+			newIndex++
+		case '-':
+			oldIndex++
+		case '+':
+			newIndex++
+		}
+	}
+	return indices
+}
+
+// BlameFile describes where each line of a gitignore file came from, using
+// the blame of the gitignore patterns file of each section, by the index of
+// the section among those of the file, as given by SectionNames, so that
+// sections of the same name are told apart. Lines of sections not in
+// templates are local.
+func BlameFile(contents string, templates map[int]TemplateBlame) []LineBlame {
+	lines := splitLines(contents)
+	blames := make([]LineBlame, len(lines))
+	for i, line := range lines {
+		blames[i] = LineBlame{LineNumber: i + 1, Line: line, Origin: OriginLocal}
+	}
+	section, sectionIndex, sectionStart := "", -1, -1
+	endSection := func(end int) {
+		if sectionStart >= 0 {
+			template, ok := templates[sectionIndex]
+			blameSection(blames[sectionStart:end], section, template, ok)
+		}
+		section, sectionStart = "", -1
+	}
+	for i := 0; i < len(lines); i++ {
+		switch {
+		case lines[i] == ManagedBeginMarker:
+			endSection(i)
+			blames[i].Origin = OriginGenerated
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "# ") {
+				i++
+				blames[i].Origin = OriginGenerated
+			}
+			if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) == "" {
+				i++
+				blames[i].Origin = OriginGenerated
+			}
+		case lines[i] == ManagedEndMarker:
+			endSection(i)
+			blames[i].Origin = OriginGenerated
+		case i+2 < len(lines):
+			name, ok := parseSectionHeader(lines[i : i+3])
+			if !ok {
+				continue
+			}
+			endSection(i)
+			for j := i; j < i+3; j++ {
+				blames[j].Section = name
+				blames[j].Origin = OriginGenerated
+			}
+			section, sectionStart = name, i+3
+			sectionIndex++
+			i += 2
+		}
+	}
+	endSection(len(lines))
+	return blames
+}
+
+// blameSection describes where the lines of the body of a section came from,
+// using the blame of its gitignore patterns file, if any. Blank lines
+// surrounding the body separate sections, so were generated.
+func blameSection(blames []LineBlame, section string, template TemplateBlame, ok bool) {
+	start, end := 0, len(blames)
+	for start < end && strings.TrimSpace(blames[start].Line) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(blames[end-1].Line) == "" {
+		end--
+	}
+	for i := range blames {
+		blames[i].Section = section
+		if i < start || i >= end {
+			blames[i].Origin = OriginGenerated
+		}
+	}
+	if !ok {
+		return
+	}
+	body := make([]string, end-start)
+	for i := range body {
+		body[i] = blames[start+i].Line
+	}
+	for bodyIndex, templateIndex := range unchangedLineIndices(template.Lines, body) {
+		blames[start+bodyIndex].Origin = OriginUpstream
+		blames[start+bodyIndex].Commit = template.Commits[templateIndex]
+		if template.Commits[templateIndex].SHA == "" {
+			blames[start+bodyIndex].OlderThan = template.OlderThan
+		}
+	}
+}
+
+// WriteBlame writes each line of a gitignore file preceded by its section
+// and where it came from: the commit that last changed it, "older than" the
+// commit before which it was last changed, "local", or "getignore"
+func WriteBlame(blameFile io.Writer, blames []LineBlame) error {
+	sectionWidth, originWidth, numberWidth := 0, 0, len(fmt.Sprint(len(blames)))
+	origins := make([]string, len(blames))
+	for i, blame := range blames {
+		origins[i] = blame.Origin
+		if blame.Origin == OriginUpstream && blame.Commit.SHA == "" {
+			origins[i] = fmt.Sprintf("older than %s", shortSHA(blame.OlderThan.SHA))
+		} else if blame.Origin == OriginUpstream {
+			commit := blame.Commit
+			origins[i] = fmt.Sprintf("%s %s %s", shortSHA(commit.SHA), commit.Date.Format("2006-01-02"), commit.Author)
+		}
+		sectionWidth = max(sectionWidth, len(blame.Section))
+		originWidth = max(originWidth, len(origins[i]))
+	}
+	writer := bufio.NewWriter(blameFile)
+	for i, blame := range blames {
+		fmt.Fprintf(writer, "%-*s  %-*s  %*d) %s\n", sectionWidth, blame.Section, originWidth, origins[i], numberWidth, blame.LineNumber, blame.Line)
+	}
+	return writer.Flush()
+}
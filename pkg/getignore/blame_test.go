@@ -0,0 +1,195 @@
+package getignore_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Blame", func() {
+	var (
+		first = getignore.Commit{
+			SHA:    "7e2b0b0d7f0a6e8f0d1c2b3a4f5e6d7c8b9a0f1e",
+			Author: "Mona Lisa",
+			Date:   time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+		}
+		second = getignore.Commit{
+			SHA:    "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+			Author: "Octo Cat",
+			Date:   time.Date(2021, 10, 14, 8, 30, 0, 0, time.UTC),
+		}
+		third = getignore.Commit{
+			SHA:    "66fd13c903cac02eb9657cd53fb227823484401d",
+			Author: "Hu Bot",
+			Date:   time.Date(2022, 3, 4, 12, 0, 0, 0, time.UTC),
+		}
+		revisions = []getignore.Revision{
+			{Commit: third, Contents: "# Binaries\n*.exe\n*.o\n*.test\n"},
+			{Commit: second, Contents: "# Binaries\n*.exe\n*.so\n*.test\n"},
+			{Commit: first, Contents: "# Binaries\n*.exe\n"},
+		}
+	)
+
+	Describe("BlameRevisions", func() {
+		It("should attribute each line to the commit that last changed it", func() {
+			Expect(getignore.BlameRevisions(revisions)).Should(Equal(getignore.TemplateBlame{
+				Lines:   []string{"# Binaries", "*.exe", "*.o", "*.test"},
+				Commits: []getignore.Commit{first, first, third, second},
+			}))
+		})
+
+		It("should attribute all lines of a single revision to it", func() {
+			Expect(getignore.BlameRevisions(revisions[2:]).Commits).Should(Equal([]getignore.Commit{first, first}))
+		})
+
+		It("should handle no revisions", func() {
+			Expect(getignore.BlameRevisions(nil)).Should(Equal(getignore.TemplateBlame{}))
+		})
+	})
+
+	Describe("BlameCommits", func() {
+		var got []string
+
+		getContents := func(commit getignore.Commit) (string, error) {
+			got = append(got, commit.SHA)
+			for _, revision := range revisions {
+				if revision.Commit.SHA == commit.SHA {
+					return revision.Contents, nil
+				}
+			}
+			return "", errors.New("no such commit")
+		}
+
+		BeforeEach(func() {
+			got = nil
+		})
+
+		It("should attribute lines unchanged in all the commits to none when incomplete", func() {
+			blame, err := getignore.BlameCommits([]getignore.Commit{third, second}, false, getContents)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(blame).Should(Equal(getignore.TemplateBlame{
+				Lines:     []string{"# Binaries", "*.exe", "*.o", "*.test"},
+				Commits:   []getignore.Commit{{}, {}, third, {}},
+				OlderThan: second,
+			}))
+		})
+
+		It("should get only the revisions needed", func() {
+			rewritten := map[string]string{second.SHA: "*.a\n*.b\n", first.SHA: "*.c\n"}
+			blame, err := getignore.BlameCommits([]getignore.Commit{second, first, third}, true, func(commit getignore.Commit) (string, error) {
+				got = append(got, commit.SHA)
+				return rewritten[commit.SHA], nil
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(blame.Commits).Should(Equal([]getignore.Commit{second, second}))
+			Expect(got).Should(Equal([]string{second.SHA, first.SHA}))
+		})
+
+		It("should return errors getting revisions", func() {
+			_, err := getignore.BlameCommits([]getignore.Commit{{SHA: "missing"}}, true, getContents)
+			Expect(err).Should(MatchError("no such commit"))
+		})
+	})
+
+	Describe("BlameFile", func() {
+		contents := `*.log
+
+# BEGIN getignore
+# source: github/gitignore@main
+
+######
+# Go #
+######
+# Binaries
+*.exe
+*.bak
+*.o
+
+
+##########
+# Custom #
+##########
+tmp/
+
+# END getignore
+`
+
+		It("should describe where each line came from", func() {
+			templates := map[int]getignore.TemplateBlame{0: getignore.BlameRevisions(revisions)}
+			blames := getignore.BlameFile(contents, templates)
+			origins := make([]string, len(blames))
+			sections := make([]string, len(blames))
+			for i, blame := range blames {
+				origins[i] = blame.Origin
+				sections[i] = blame.Section
+			}
+			Expect(origins).Should(Equal([]string{
+				"local", "local",
+				"getignore", "getignore", "getignore",
+				"getignore", "getignore", "getignore",
+				"upstream", "upstream", "local", "upstream",
+				"getignore", "getignore",
+				"getignore", "getignore", "getignore",
+				"local",
+				"getignore", "getignore",
+			}))
+			Expect(sections).Should(Equal([]string{
+				"", "",
+				"", "", "",
+				"Go", "Go", "Go",
+				"Go", "Go", "Go", "Go",
+				"Go", "Go",
+				"Custom", "Custom", "Custom",
+				"Custom",
+				"Custom", "",
+			}))
+			Expect(blames[8].Commit).Should(Equal(first))
+			Expect(blames[11].Commit).Should(Equal(third))
+			Expect(blames[11].LineNumber).Should(Equal(12))
+		})
+
+		It("should tell apart sections of the same name by their index", func() {
+			vims := `#######
+# Vim #
+#######
+*.swp
+
+#######
+# Vim #
+#######
+*.swo
+`
+			blames := getignore.BlameFile(vims, map[int]getignore.TemplateBlame{
+				0: {Lines: []string{"*.swp"}, Commits: []getignore.Commit{first}},
+				1: {Lines: []string{"*.swo"}, Commits: []getignore.Commit{second}},
+			})
+			Expect(blames[3].Origin).Should(Equal("upstream"))
+			Expect(blames[3].Commit).Should(Equal(first))
+			Expect(blames[8].Origin).Should(Equal("upstream"))
+			Expect(blames[8].Commit).Should(Equal(second))
+		})
+	})
+
+	Describe("WriteBlame", func() {
+		It("should write each line with its section and origin", func() {
+			outputFile := bytes.NewBufferString("")
+			blames := []getignore.LineBlame{
+				{LineNumber: 1, Line: "######", Section: "Go", Origin: "getignore"},
+				{LineNumber: 2, Line: "*.o", Section: "Go", Origin: "upstream", Commit: third},
+				{LineNumber: 3, Line: "*.log", Origin: "local"},
+				{LineNumber: 4, Line: "*.exe", Section: "Go", Origin: "upstream", OlderThan: first},
+			}
+			Expect(getignore.WriteBlame(outputFile, blames)).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal(`Go  getignore                  1) ######
+Go  66fd13c 2022-03-04 Hu Bot  2) *.o
+    local                      3) *.log
+Go  older than 7e2b0b0         4) *.exe
+`))
+		})
+	})
+})
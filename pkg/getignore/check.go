@@ -0,0 +1,175 @@
+package getignore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Formats in which to write findings
+const (
+	FindingsFormatText   = "text"
+	FindingsFormatJSON   = "json"
+	FindingsFormatGitHub = "github"
+)
+
+// Finding is a problem found checking the managed section of a gitignore
+// file
+type Finding struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Section string `json:"section,omitempty"`
+	Message string `json:"message"`
+}
+
+// CheckNames returns a finding if the names requested differ from those
+// recorded by the lock
+func CheckNames(path string, mf ManagedFile, namesList NamesList, lock Lock) []Finding {
+	if equalStrings(namesList.Names, lock.Names) && equalStrings(namesList.Exclusions, lock.Exclusions) {
+		return nil
+	}
+	return []Finding{{
+		File:    path,
+		Line:    mf.BeginLine,
+		Message: "the names have changed since the managed section was generated; run `getignore update`",
+	}}
+}
+
+// CheckLocalFile returns a finding if the local file, with its current
+// contents, has changed since the managed section was generated from the
+// version recorded by the lock
+func CheckLocalFile(path string, mf ManagedFile, lf LockedFile, nc NamedContents) []Finding {
+	if BlobSHA(nc.Contents) == lf.SHA {
+		return nil
+	}
+	return []Finding{{
+		File:    path,
+		Line:    mf.BeginLine,
+		Section: nc.DisplayName(),
+		Message: fmt.Sprintf("local file %s has changed since the managed section was generated; run `getignore update`", lf.Path),
+	}}
+}
+
+// CheckSections compares the sections of the managed section with those
+// expected, returning a finding for each section that is missing, not
+// expected, or differs from what is expected. As section headers record only
+// the base names of files, each expected file is matched, in order, with the
+// first section not yet matched with the same name, so that files with the
+// same base name, e.g., Global/Vim.gitignore and community/Vim.gitignore,
+// are told apart; findings name expected files by their full names. The
+// sections of the changed local files, with the names, are only checked for
+// being present, as the versions recorded by the lock are no longer known,
+// and CheckLocalFile reports the changes.
+func CheckSections(path string, mf ManagedFile, expected []NamedContents, changedLocalFiles []string) []Finding {
+	unmatched := make(map[string][]int, len(mf.Sections))
+	for i, section := range mf.Sections {
+		name := section.DisplayName()
+		unmatched[name] = append(unmatched[name], i)
+	}
+	changed := make(map[string]bool, len(changedLocalFiles))
+	for _, name := range changedLocalFiles {
+		changed[name] = true
+	}
+	var findings []Finding
+	for _, nc := range expected {
+		name := sectionName(nc)
+		indices := unmatched[nc.DisplayName()]
+		if len(indices) == 0 {
+			findings = append(findings, Finding{
+				File:    path,
+				Line:    mf.BeginLine,
+				Section: name,
+				Message: fmt.Sprintf("section %s is missing", name),
+			})
+			continue
+		}
+		i := indices[0]
+		unmatched[nc.DisplayName()] = indices[1:]
+		if !changed[nc.Name] && strings.TrimSpace(mf.Sections[i].Contents) != strings.TrimSpace(nc.Contents) {
+			findings = append(findings, Finding{
+				File:    path,
+				Line:    mf.SectionLines[i],
+				Section: name,
+				Message: fmt.Sprintf("section %s differs from the version in the lock file", name),
+			})
+		}
+	}
+	var extra []int
+	for _, indices := range unmatched {
+		extra = append(extra, indices...)
+	}
+	sort.Ints(extra)
+	for _, i := range extra {
+		name := mf.Sections[i].DisplayName()
+		findings = append(findings, Finding{
+			File:    path,
+			Line:    mf.SectionLines[i],
+			Section: name,
+			Message: fmt.Sprintf("section %s is not in the lock file", name),
+		})
+	}
+	return findings
+}
+
+// sectionName returns the full name of the file of a section, without its
+// extension, e.g., Global/Vim
+func sectionName(nc NamedContents) string {
+	return strings.TrimSuffix(nc.Name, path.Ext(nc.Name))
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteFindings writes the findings in the format: "text", one per line;
+// "json", as an array of objects; or "github", as GitHub Actions workflow
+// error annotations
+func WriteFindings(w io.Writer, findings []Finding, format string) error {
+	switch format {
+	case FindingsFormatText:
+		for _, f := range findings {
+			if _, err := fmt.Fprintf(w, "%s:%d: %s\n", f.File, f.Line, f.Message); err != nil {
+				return err
+			}
+		}
+	case FindingsFormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	case FindingsFormatGitHub:
+		for _, f := range findings {
+			if _, err := fmt.Fprintf(w, "::error file=%s,line=%d::%s\n", escapeAnnotationProperty(f.File), f.Line, escapeAnnotationData(f.Message)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid format: %s", format)
+	}
+	return nil
+}
+
+var annotationDataReplacer = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+var annotationPropertyReplacer = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+func escapeAnnotationData(s string) string {
+	return annotationDataReplacer.Replace(s)
+}
+
+func escapeAnnotationProperty(s string) string {
+	return annotationPropertyReplacer.Replace(s)
+}
@@ -0,0 +1,159 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Check", func() {
+	mf := getignore.ManagedFile{
+		BeginLine: 3,
+		Sections: []getignore.NamedContents{
+			{Name: "Go.gitignore", Contents: "*.o\n*.exe\n"},
+			{Name: "Hand.gitignore", Contents: "hand/\n"},
+		},
+		SectionLines: []int{9, 16},
+	}
+
+	Describe("CheckSections", func() {
+		It("should find nothing when the sections match", func() {
+			findings := getignore.CheckSections(".gitignore", mf, []getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "\n*.o\n*.exe"},
+				{Name: "Hand.gitignore", Contents: "hand/\n"},
+			}, nil)
+			Expect(findings).Should(BeEmpty())
+		})
+
+		It("should find sections that differ, are missing, or are not expected", func() {
+			findings := getignore.CheckSections(".gitignore", mf, []getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n"},
+				{Name: "Node.gitignore", Contents: "node_modules/\n"},
+			}, nil)
+			Expect(findings).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 9, Section: "Go", Message: "section Go differs from the version in the lock file"},
+				{File: ".gitignore", Line: 3, Section: "Node", Message: "section Node is missing"},
+				{File: ".gitignore", Line: 16, Section: "Hand", Message: "section Hand is not in the lock file"},
+			}))
+		})
+
+		It("should only check that sections of changed local files are present", func() {
+			localFile := getignore.ManagedFile{
+				BeginLine:    3,
+				Sections:     []getignore.NamedContents{{Name: "extra.gitignore", Contents: "extra/\n"}},
+				SectionLines: []int{9},
+			}
+			expected := []getignore.NamedContents{
+				{Name: "./extra.gitignore", Contents: "more/\n", Source: getignore.LocalSource},
+				{Name: "./other.gitignore", Contents: "other/\n", Source: getignore.LocalSource},
+			}
+			Expect(getignore.CheckSections(".gitignore", localFile, expected, []string{"./extra.gitignore", "./other.gitignore"})).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 3, Section: "./other", Message: "section ./other is missing"},
+			}))
+			Expect(getignore.CheckSections(".gitignore", localFile, expected[:1], nil)).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 9, Section: "./extra", Message: "section ./extra differs from the version in the lock file"},
+			}))
+		})
+
+		It("should tell apart files with the same base name", func() {
+			vimFile := getignore.ManagedFile{
+				BeginLine: 3,
+				Sections: []getignore.NamedContents{
+					{Name: "Vim.gitignore", Contents: "*.swp\n"},
+					{Name: "Vim.gitignore", Contents: "*.swo\n"},
+				},
+				SectionLines: []int{9, 16},
+			}
+			findings := getignore.CheckSections(".gitignore", vimFile, []getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "community/Vim.gitignore", Contents: "*.swo\n*~\n"},
+			}, nil)
+			Expect(findings).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 16, Section: "community/Vim", Message: "section community/Vim differs from the version in the lock file"},
+			}))
+		})
+
+		It("should find a missing file with the same base name as another", func() {
+			vimFile := getignore.ManagedFile{
+				BeginLine:    3,
+				Sections:     []getignore.NamedContents{{Name: "Vim.gitignore", Contents: "*.swp\n"}},
+				SectionLines: []int{9},
+			}
+			findings := getignore.CheckSections(".gitignore", vimFile, []getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "community/Vim.gitignore", Contents: "*.swo\n"},
+			}, nil)
+			Expect(findings).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 3, Section: "community/Vim", Message: "section community/Vim is missing"},
+			}))
+		})
+	})
+
+	Describe("CheckLocalFile", func() {
+		lf := getignore.LockedFile{Source: getignore.LocalSource, Path: "./extra.gitignore", SHA: getignore.BlobSHA("extra/\n")}
+
+		It("should find nothing when the local file is unchanged", func() {
+			Expect(getignore.CheckLocalFile(".gitignore", mf, lf, getignore.NamedContents{Name: "./extra.gitignore", Contents: "extra/\n"})).Should(BeEmpty())
+		})
+
+		It("should find a changed local file", func() {
+			Expect(getignore.CheckLocalFile(".gitignore", mf, lf, getignore.NamedContents{Name: "./extra.gitignore", Contents: "more/\n"})).Should(Equal([]getignore.Finding{
+				{File: ".gitignore", Line: 3, Section: "extra", Message: "local file ./extra.gitignore has changed since the managed section was generated; run `getignore update`"},
+			}))
+		})
+	})
+
+	Describe("CheckNames", func() {
+		lock := getignore.Lock{Names: []string{"Go"}, Exclusions: []string{"Global/Vim"}}
+
+		It("should find nothing when the names match", func() {
+			namesList := getignore.NamesList{Names: []string{"Go"}, Exclusions: []string{"Global/Vim"}}
+			Expect(getignore.CheckNames(".gitignore", mf, namesList, lock)).Should(BeEmpty())
+		})
+
+		It("should find changed names", func() {
+			namesList := getignore.NamesList{Names: []string{"Go", "Node"}, Exclusions: []string{"Global/Vim"}}
+			findings := getignore.CheckNames(".gitignore", mf, namesList, lock)
+			Expect(findings).Should(HaveLen(1))
+			Expect(findings[0].Line).Should(Equal(3))
+		})
+	})
+
+	Describe("WriteFindings", func() {
+		var outputFile *bytes.Buffer
+		findings := []getignore.Finding{
+			{File: "dir,x/.gitignore", Line: 9, Section: "Go", Message: "section Go differs\n100%"},
+		}
+
+		BeforeEach(func() {
+			outputFile = bytes.NewBufferString("")
+		})
+
+		It("should write text", func() {
+			Expect(getignore.WriteFindings(outputFile, findings[:0], "text")).Should(Succeed())
+			Expect(outputFile.String()).Should(BeEmpty())
+			Expect(getignore.WriteFindings(outputFile, findings, "text")).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal("dir,x/.gitignore:9: section Go differs\n100%\n"))
+		})
+
+		It("should write JSON", func() {
+			Expect(getignore.WriteFindings(outputFile, nil, "json")).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal("[]\n"))
+			outputFile.Reset()
+			Expect(getignore.WriteFindings(outputFile, findings, "json")).Should(Succeed())
+			Expect(outputFile.String()).Should(MatchJSON(`[{"file": "dir,x/.gitignore", "line": 9, "section": "Go", "message": "section Go differs\n100%"}]`))
+		})
+
+		It("should write escaped GitHub annotations", func() {
+			Expect(getignore.WriteFindings(outputFile, findings, "github")).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal("::error file=dir%2Cx/.gitignore,line=9::section Go differs%0A100%25\n"))
+		})
+
+		It("should fail for unknown formats", func() {
+			Expect(getignore.WriteFindings(outputFile, findings, "xml")).Should(MatchError("invalid format: xml"))
+		})
+	})
+})
@@ -0,0 +1,54 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// revisionRangeSeparator separates the revisions of a range, as in Git
+const revisionRangeSeparator = ".."
+
+// Commit represents a commit in the repository of gitignore patterns files
+type Commit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Message string
+}
+
+// Summary returns the first line of the commit message
+func (c Commit) Summary() string {
+	return strings.SplitN(strings.TrimSpace(c.Message), "\n", 2)[0]
+}
+
+// String returns the abbreviated SHA, date, author, and summary of the
+// commit on one line
+func (c Commit) String() string {
+	return fmt.Sprintf("%s %s %s: %s", shortSHA(c.SHA), c.Date.Format("2006-01-02"), c.Author, c.Summary())
+}
+
+// WriteCommits writes each commit on a line
+func WriteCommits(commitsFile io.Writer, commits []Commit) error {
+	writer := bufio.NewWriter(commitsFile)
+	for _, commit := range commits {
+		fmt.Fprintln(writer, commit)
+	}
+	return writer.Flush()
+}
+
+// ParseRevisionRange parses a range of revisions of the form OLD..NEW, where
+// either may be a branch, tag, or commit. NEW is empty if it is omitted, as
+// is the separator.
+func ParseRevisionRange(revisions string) (string, string, error) {
+	oldRevision, newRevision := revisions, ""
+	if i := strings.Index(revisions, revisionRangeSeparator); i >= 0 {
+		oldRevision, newRevision = revisions[:i], revisions[i+len(revisionRangeSeparator):]
+	}
+	if oldRevision == "" {
+		return "", "", fmt.Errorf("invalid revision range %q: missing the old revision", revisions)
+	}
+	return oldRevision, newRevision, nil
+}
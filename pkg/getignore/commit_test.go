@@ -0,0 +1,59 @@
+package getignore_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Commit", func() {
+	commit := getignore.Commit{
+		SHA:     "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+		Author:  "Octo Cat",
+		Date:    time.Date(2021, 10, 14, 8, 30, 0, 0, time.UTC),
+		Message: "Add Go workspace file\n\nGo 1.18 introduces go.work files.\n",
+	}
+
+	It("should summarize the message with its first line", func() {
+		Expect(commit.Summary()).Should(Equal("Add Go workspace file"))
+	})
+
+	It("should describe the commit on one line", func() {
+		Expect(commit.String()).Should(Equal("b0012e4 2021-10-14 Octo Cat: Add Go workspace file"))
+	})
+
+	It("should write commits one per line", func() {
+		outputFile := bytes.NewBufferString("")
+		Expect(getignore.WriteCommits(outputFile, []getignore.Commit{commit, commit})).Should(Succeed())
+		Expect(outputFile.String()).Should(Equal(
+			"b0012e4 2021-10-14 Octo Cat: Add Go workspace file\nb0012e4 2021-10-14 Octo Cat: Add Go workspace file\n",
+		))
+	})
+
+	Describe("ParseRevisionRange", func() {
+		It("should parse both revisions", func() {
+			oldRevision, newRevision, err := getignore.ParseRevisionRange("v1.0..main")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(oldRevision).Should(Equal("v1.0"))
+			Expect(newRevision).Should(Equal("main"))
+		})
+
+		It("should leave out an omitted new revision", func() {
+			for _, revisions := range []string{"b0012e4..", "b0012e4"} {
+				oldRevision, newRevision, err := getignore.ParseRevisionRange(revisions)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(oldRevision).Should(Equal("b0012e4"))
+				Expect(newRevision).Should(BeEmpty())
+			}
+		})
+
+		It("should fail without an old revision", func() {
+			_, _, err := getignore.ParseRevisionRange("..main")
+			Expect(err).Should(MatchError(`invalid revision range "..main": missing the old revision`))
+		})
+	})
+})
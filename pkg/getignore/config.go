@@ -0,0 +1,68 @@
+package getignore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ConfigFileName is the name of the getignore configuration file within the
+// user's configuration directory
+const ConfigFileName = "config.json"
+
+// Config represents user settings for getignore
+type Config struct {
+	// Aliases maps alternative names to names of gitignore patterns files,
+	// e.g., "jetbrains" to "Global/JetBrains"
+	Aliases map[string]string `json:"aliases"`
+	// Sources are the sources to get files from, in order of precedence,
+	// e.g., an organization's repository before github/gitignore, as given by
+	// SplitSource
+	Sources []string `json:"sources"`
+}
+
+// DefaultConfigPath returns the path to the configuration file in the user's
+// configuration directory, or an empty string if there is none
+func DefaultConfigPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "getignore", ConfigFileName)
+}
+
+// ParseConfig reads configuration in JSON format
+func ParseConfig(configFile io.Reader) (Config, error) {
+	var config Config
+	decoder := json.NewDecoder(configFile)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return config, nil
+}
+
+// LoadConfig reads the configuration file at the given path. If the file
+// does not exist and mustExist is false, an empty configuration is returned.
+func LoadConfig(path string, mustExist bool) (Config, error) {
+	if path == "" {
+		return Config{}, nil
+	}
+	configFile, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) && !mustExist {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("unable to read configuration: %w", err)
+	}
+	defer configFile.Close()
+	config, err := ParseConfig(configFile)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
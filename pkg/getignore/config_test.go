@@ -0,0 +1,80 @@
+package getignore_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Config", func() {
+	Describe("ParseConfig", func() {
+		It("parses aliases", func() {
+			config, err := getignore.ParseConfig(strings.NewReader(`{
+  "aliases": {
+    "node": "Node",
+    "jetbrains": "Global/JetBrains"
+  }
+}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(config.Aliases).Should(Equal(map[string]string{
+				"node":      "Node",
+				"jetbrains": "Global/JetBrains",
+			}))
+		})
+
+		It("parses sources in order of precedence", func() {
+			config, err := getignore.ParseConfig(strings.NewReader(`{"sources": ["acme/templates@main", "github/gitignore@master"]}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(config.Sources).Should(Equal([]string{"acme/templates@main", "github/gitignore@master"}))
+		})
+
+		It("accepts an empty file", func() {
+			config, err := getignore.ParseConfig(strings.NewReader(""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(config).Should(Equal(getignore.Config{}))
+		})
+
+		It("rejects unknown settings", func() {
+			_, err := getignore.ParseConfig(strings.NewReader(`{"alias": {}}`))
+			Expect(err).Should(MatchError(HavePrefix("invalid configuration:")))
+		})
+	})
+
+	Describe("LoadConfig", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "getignore-config")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads the file", func() {
+			path := filepath.Join(dir, getignore.ConfigFileName)
+			Expect(os.WriteFile(path, []byte(`{"aliases": {"node": "Node"}}`), 0o644)).Should(Succeed())
+			config, err := getignore.LoadConfig(path, true)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(config.Aliases).Should(HaveKeyWithValue("node", "Node"))
+		})
+
+		It("returns an empty configuration for a missing optional file", func() {
+			config, err := getignore.LoadConfig(filepath.Join(dir, "missing.json"), false)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(config).Should(Equal(getignore.Config{}))
+		})
+
+		It("fails for a missing required file", func() {
+			_, err := getignore.LoadConfig(filepath.Join(dir, "missing.json"), true)
+			Expect(err).Should(MatchError(HavePrefix("unable to read configuration:")))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package getignore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around changes
+const diffContextLines = 3
+
+// diffOp is one line of an edit script: unchanged (' '), removed ('-'), or
+// added ('+')
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns the changes from the old contents to the new contents
+// in unified format, labeling them with the old and new names, or an empty
+// string if the contents are the same
+func UnifiedDiff(oldName string, newName string, oldContents string, newContents string) string {
+	if oldContents == newContents {
+		return ""
+	}
+	ops := diffLines(splitLines(oldContents), splitLines(newContents))
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&b, ops, h)
+	}
+	return b.String()
+}
+
+func splitLines(contents string) []string {
+	if contents == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(contents, "\n"), "\n")
+}
+
+// diffLines computes an edit script from the old lines to the new lines
+// using their longest common subsequence
+func diffLines(oldLines []string, newLines []string) []diffOp {
+	n, m := len(oldLines), len(newLines)
+	// lcs[i][j] is the length of the longest common subsequence of
+	// oldLines[i:] and newLines[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{' ', oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', oldLines[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', newLines[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', oldLines[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', newLines[j]})
+	}
+	return ops
+}
+
+// hunk is a range of an edit script, [start, end)
+type hunk struct {
+	start int
+	end   int
+}
+
+// hunks groups the changes of an edit script with their surrounding context,
+// merging groups whose context would overlap
+func hunks(ops []diffOp) []hunk {
+	var result []hunk
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start := max(i-diffContextLines, 0)
+		end := min(i+diffContextLines+1, len(ops))
+		if len(result) > 0 && start <= result[len(result)-1].end {
+			result[len(result)-1].end = end
+		} else {
+			result = append(result, hunk{start, end})
+		}
+	}
+	return result
+}
+
+func writeHunk(b *strings.Builder, ops []diffOp, h hunk) {
+	oldStart, newStart := 0, 0
+	for _, op := range ops[:h.start] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, op := range ops[h.start:h.end] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, op := range ops[h.start:h.end] {
+		fmt.Fprintf(b, "%c%s\n", op.kind, op.line)
+	}
+}
+
+// hunkRange formats the start and count of lines of a hunk, where start is
+// the number of lines preceding the hunk
+func hunkRange(start int, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
@@ -0,0 +1,96 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("UnifiedDiff", func() {
+	It("should return nothing for the same contents", func() {
+		Expect(getignore.UnifiedDiff("a", "b", "*.o\n", "*.o\n")).Should(BeEmpty())
+	})
+
+	It("should show changes with surrounding context", func() {
+		oldContents := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+		newContents := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n"
+		Expect(getignore.UnifiedDiff("a/.gitignore", "b/.gitignore", oldContents, newContents)).Should(Equal(`--- a/.gitignore
++++ b/.gitignore
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`))
+	})
+
+	It("should merge changes whose context overlaps", func() {
+		oldContents := "1\n2\n3\n4\n5\n6\n"
+		newContents := "one\n2\n3\n4\n5\nsix\n"
+		Expect(getignore.UnifiedDiff("a", "b", oldContents, newContents)).Should(Equal(`--- a
++++ b
+@@ -1,6 +1,6 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+-6
++six
+`))
+	})
+
+	It("should merge changes separated by twice the context", func() {
+		oldContents := "1\n2\n3\n4\n5\n6\n7\n8\n"
+		newContents := "one\n2\n3\n4\n5\n6\n7\neight\n"
+		Expect(getignore.UnifiedDiff("a", "b", oldContents, newContents)).Should(Equal(`--- a
++++ b
+@@ -1,8 +1,8 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+ 7
+-8
++eight
+`))
+	})
+
+	It("should keep changes separated by more than twice the context in their own hunks", func() {
+		oldContents := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+		newContents := "one\n2\n3\n4\n5\n6\n7\n8\nnine\n"
+		Expect(getignore.UnifiedDiff("a", "b", oldContents, newContents)).Should(Equal(`--- a
++++ b
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -6,4 +6,4 @@
+ 6
+ 7
+ 8
+-9
++nine
+`))
+	})
+
+	It("should show all lines added to empty contents", func() {
+		Expect(getignore.UnifiedDiff("a", "b", "", "*.o\n")).Should(Equal("--- a\n+++ b\n@@ -0,0 +1 @@\n+*.o\n"))
+	})
+})
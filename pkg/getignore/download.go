@@ -0,0 +1,56 @@
+package getignore
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = runtime.NumCPU() - 1
+
+// DownloadFunc downloads the contents of the file at the path
+type DownloadFunc func(ctx context.Context, path string) (string, error)
+
+// DownloadFiles downloads the files at the paths, making at most maxRequests
+// requests at once. It returns the contents of the files downloaded, in the
+// order of their paths, and the files that failed to download.
+func DownloadFiles(ctx context.Context, paths []string, maxRequests int, download DownloadFunc) ([]NamedContents, FailedFiles) {
+	contents := make([]NamedContents, len(paths))
+	errs := make([]error, len(paths))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(len(paths), max(maxRequests, 1)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				fileContents, err := download(ctx, paths[index])
+				contents[index] = NamedContents{Name: paths[index], Contents: fileContents}
+				errs[index] = err
+			}
+		}()
+	}
+	for i := range paths {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	var (
+		downloaded  []NamedContents
+		failedFiles FailedFiles
+	)
+	for i, err := range errs {
+		if err != nil {
+			failedFiles = append(failedFiles, FailedFile{
+				Name:    paths[i],
+				Message: "failed to download",
+				Err:     err,
+			})
+		} else {
+			downloaded = append(downloaded, contents[i])
+		}
+	}
+	return downloaded, failedFiles
+}
@@ -0,0 +1,89 @@
+package getignore_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	getignore "github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("DownloadFiles", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("returns the contents in the order of the paths", func() {
+		paths := []string{"Go.gitignore", "Global/Vim.gitignore", "Python.gitignore"}
+		delays := map[string]time.Duration{"Go.gitignore": 6, "Global/Vim.gitignore": 3, "Python.gitignore": 0}
+		contents, failedFiles := getignore.DownloadFiles(ctx, paths, 3, func(ctx context.Context, path string) (string, error) {
+			// later paths finish first
+			time.Sleep(delays[path] * time.Millisecond)
+			return "contents of " + path, nil
+		})
+		Expect(failedFiles).Should(BeNil())
+		Expect(contents).Should(Equal([]getignore.NamedContents{
+			{Name: "Go.gitignore", Contents: "contents of Go.gitignore"},
+			{Name: "Global/Vim.gitignore", Contents: "contents of Global/Vim.gitignore"},
+			{Name: "Python.gitignore", Contents: "contents of Python.gitignore"},
+		}))
+	})
+
+	It("returns the files that failed to download", func() {
+		paths := []string{"Go.gitignore", "Missing.gitignore", "Python.gitignore"}
+		contents, failedFiles := getignore.DownloadFiles(ctx, paths, 2, func(ctx context.Context, path string) (string, error) {
+			if strings.HasPrefix(path, "Missing") {
+				return "", errors.New("not found")
+			}
+			return "contents of " + path, nil
+		})
+		Expect(contents).Should(Equal([]getignore.NamedContents{
+			{Name: "Go.gitignore", Contents: "contents of Go.gitignore"},
+			{Name: "Python.gitignore", Contents: "contents of Python.gitignore"},
+		}))
+		Expect(failedFiles).Should(HaveLen(1))
+		Expect(failedFiles[0]).Should(MatchError("failed to get Missing.gitignore: failed to download"))
+		Expect(errors.Unwrap(failedFiles[0])).Should(MatchError("not found"))
+	})
+
+	It("makes at most the maximum number of requests at once", func() {
+		var current, highest int32
+		paths := []string{"A.gitignore", "B.gitignore", "C.gitignore", "D.gitignore", "E.gitignore"}
+		_, failedFiles := getignore.DownloadFiles(ctx, paths, 2, func(ctx context.Context, path string) (string, error) {
+			n := atomic.AddInt32(&current, 1)
+			for {
+				h := atomic.LoadInt32(&highest)
+				if n <= h || atomic.CompareAndSwapInt32(&highest, h, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			atomic.AddInt32(&current, -1)
+			return "", nil
+		})
+		Expect(failedFiles).Should(BeNil())
+		Expect(highest).Should(BeNumerically("<=", 2))
+	})
+
+	It("makes requests one at a time if the maximum is less than one", func() {
+		contents, _ := getignore.DownloadFiles(ctx, []string{"Go.gitignore"}, 0, func(ctx context.Context, path string) (string, error) {
+			return "*.o", nil
+		})
+		Expect(contents).Should(Equal([]getignore.NamedContents{{Name: "Go.gitignore", Contents: "*.o"}}))
+	})
+
+	It("returns nothing for no paths", func() {
+		contents, failedFiles := getignore.DownloadFiles(ctx, nil, 2, func(ctx context.Context, path string) (string, error) {
+			return "", nil
+		})
+		Expect(contents).Should(BeEmpty())
+		Expect(failedFiles).Should(BeNil())
+	})
+})
@@ -7,19 +7,28 @@ import (
 
 // FailedFile represents a gitignore file unable to be retrieved or processed
 type FailedFile struct {
-	Name    string
-	Message string
-	Err     error
+	Name        string
+	Message     string
+	Err         error
+	Suggestions []string
 }
 
 func (f FailedFile) Error() string {
-	return fmt.Sprintf("failed to get %s: %s", f.Name, f.Message)
+	return fmt.Sprintf("failed to get %s: %s", f.Name, f.reason())
 }
 
 func (f FailedFile) Unwrap() error {
 	return f.Err
 }
 
+// reason describes why the file failed, including any suggested alternatives
+func (f FailedFile) reason() string {
+	if len(f.Suggestions) == 0 {
+		return f.Message
+	}
+	return fmt.Sprintf("%s (did you mean %s?)", f.Message, strings.Join(f.Suggestions, ", "))
+}
+
 // FailedFiles represents a collection of FailedFile instances
 type FailedFiles []FailedFile
 
@@ -28,7 +37,7 @@ func (e FailedFiles) Error() string {
 	reasons := make([]string, len(e))
 	for i, failedFile := range e {
 		fileNames[i] = failedFile.Name
-		reasons[i] = fmt.Sprintf("%s: %s", failedFile.Name, failedFile.Message)
+		reasons[i] = fmt.Sprintf("%s: %s", failedFile.Name, failedFile.reason())
 	}
 	filesStr := strings.Join(fileNames, ", ")
 	reasonsStr := strings.Join(reasons, "\n")
@@ -28,4 +28,13 @@ var _ = Describe("FailedFile", func() {
 	It("should support unwrapping the inner error", func() {
 		Expect(errors.Unwrap(ff)).Should(MatchError("problem connecting to the server"))
 	})
+
+	It("should include suggestions in the error message", func() {
+		ff = getignore.FailedFile{
+			Name:        "go.gitignore",
+			Message:     "not present in file tree",
+			Suggestions: []string{"Go.gitignore", "Godot.gitignore"},
+		}
+		Expect(ff).Should(MatchError("failed to get go.gitignore: not present in file tree (did you mean Go.gitignore, Godot.gitignore?)"))
+	})
 })
@@ -25,6 +25,20 @@ var _ = Describe("FailedFiles", func() {
 		expectedMsg := `failed to get the following files: Go.gitignore, Nonexistent.gitignore
 Go.gitignore: could not connect
 Nonexistent.gitignore: file not found in tree
+`
+		Expect(err).Should(MatchError(expectedMsg))
+	})
+
+	It("should include suggestions for each file", func() {
+		err := getignore.FailedFiles{
+			{
+				Name:        "vim.gitignore",
+				Message:     "not present in file tree",
+				Suggestions: []string{"Global/Vim.gitignore"},
+			},
+		}
+		expectedMsg := `failed to get the following files: vim.gitignore
+vim.gitignore: not present in file tree (did you mean Global/Vim.gitignore?)
 `
 		Expect(err).Should(MatchError(expectedMsg))
 	})
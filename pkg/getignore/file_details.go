@@ -0,0 +1,80 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences used to highlight gitignore patterns
+const (
+	ansiReset   = "\x1b[0m"
+	ansiComment = "\x1b[90m"
+	ansiNegate  = "\x1b[32m"
+	ansiLineNum = "\x1b[33m"
+)
+
+// FileDetails describes a gitignore patterns file, along with its contents
+type FileDetails struct {
+	FileEntry
+	Contents   string
+	URL        string
+	LastCommit Commit
+}
+
+// DisplayOptions controls how the contents of a file are displayed
+type DisplayOptions struct {
+	LineNumbers bool
+	Highlight   bool
+}
+
+// WriteFileDetails writes the metadata of the file followed by its contents
+func WriteFileDetails(detailsFile io.Writer, details FileDetails, opts DisplayOptions) error {
+	writer := bufio.NewWriter(detailsFile)
+	fmt.Fprintf(writer, "Path:        %s\n", details.Path)
+	fmt.Fprintf(writer, "SHA:         %s\n", details.SHA)
+	fmt.Fprintf(writer, "Size:        %d bytes\n", details.Size)
+	if details.LastCommit.SHA != "" {
+		fmt.Fprintf(writer, "Last commit: %s\n", details.LastCommit)
+	}
+	if details.URL != "" {
+		fmt.Fprintf(writer, "URL:         %s\n", details.URL)
+	}
+	writer.WriteString("\n")
+	writeContents(writer, details.Contents, opts)
+	return writer.Flush()
+}
+
+func writeContents(writer *bufio.Writer, contents string, opts DisplayOptions) {
+	lines := strings.Split(strings.TrimSuffix(contents, "\n"), "\n")
+	numberWidth := len(strconv.Itoa(len(lines)))
+	for i, line := range lines {
+		if opts.LineNumbers {
+			number := fmt.Sprintf("%*d", numberWidth, i+1)
+			if opts.Highlight {
+				number = ansiLineNum + number + ansiReset
+			}
+			writer.WriteString(number + "  ")
+		}
+		if opts.Highlight {
+			line = HighlightLine(line)
+		}
+		writer.WriteString(line + "\n")
+	}
+}
+
+// HighlightLine decorates a line of a gitignore file with ANSI colors,
+// distinguishing comments and negated patterns from other patterns
+func HighlightLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "#"):
+		return ansiComment + line + ansiReset
+	case strings.HasPrefix(trimmed, "!"):
+		return ansiNegate + line + ansiReset
+	default:
+		return line
+	}
+}
@@ -0,0 +1,84 @@
+package getignore_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("FileDetails", func() {
+	var (
+		outputFile *bytes.Buffer
+		details    getignore.FileDetails
+	)
+
+	BeforeEach(func() {
+		outputFile = bytes.NewBufferString("")
+		details = getignore.FileDetails{
+			FileEntry: getignore.FileEntry{
+				Path: "Go.gitignore",
+				SHA:  "66fd13c903cac02eb9657cd53fb227823484401d",
+				Size: 25,
+			},
+			Contents: "# Binaries\n*.exe\n!keep.exe\n",
+			URL:      "https://github.com/github/gitignore/blob/master/Go.gitignore",
+			LastCommit: getignore.Commit{
+				SHA:     "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+				Author:  "Octo Cat",
+				Date:    time.Date(2021, 10, 14, 8, 30, 0, 0, time.UTC),
+				Message: "Add Go workspace file",
+			},
+		}
+	})
+
+	It("should write the details followed by the contents", func() {
+		getignore.WriteFileDetails(outputFile, details, getignore.DisplayOptions{})
+		Expect(outputFile.String()).Should(Equal(`Path:        Go.gitignore
+SHA:         66fd13c903cac02eb9657cd53fb227823484401d
+Size:        25 bytes
+Last commit: b0012e4 2021-10-14 Octo Cat: Add Go workspace file
+URL:         https://github.com/github/gitignore/blob/master/Go.gitignore
+
+# Binaries
+*.exe
+!keep.exe
+`))
+	})
+
+	It("should leave out unknown details", func() {
+		details.LastCommit = getignore.Commit{}
+		details.URL = ""
+		getignore.WriteFileDetails(outputFile, details, getignore.DisplayOptions{})
+		Expect(outputFile.String()).ShouldNot(ContainSubstring("Last commit:"))
+		Expect(outputFile.String()).ShouldNot(ContainSubstring("URL:"))
+	})
+
+	It("should number the lines", func() {
+		details.Contents = "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+		getignore.WriteFileDetails(outputFile, details, getignore.DisplayOptions{LineNumbers: true})
+		Expect(outputFile.String()).Should(HaveSuffix("\n 1  1\n 2  2\n 3  3\n 4  4\n 5  5\n 6  6\n 7  7\n 8  8\n 9  9\n10  10\n"))
+	})
+
+	It("should highlight the contents", func() {
+		getignore.WriteFileDetails(outputFile, details, getignore.DisplayOptions{LineNumbers: true, Highlight: true})
+		Expect(outputFile.String()).Should(HaveSuffix("\n\x1b[33m1\x1b[0m  \x1b[90m# Binaries\x1b[0m\n\x1b[33m2\x1b[0m  *.exe\n\x1b[33m3\x1b[0m  \x1b[32m!keep.exe\x1b[0m\n"))
+	})
+
+	Describe("HighlightLine", func() {
+		It("should highlight comments", func() {
+			Expect(getignore.HighlightLine("# Binaries")).Should(Equal("\x1b[90m# Binaries\x1b[0m"))
+		})
+
+		It("should highlight negated patterns", func() {
+			Expect(getignore.HighlightLine("!keep.exe")).Should(Equal("\x1b[32m!keep.exe\x1b[0m"))
+		})
+
+		It("should leave other patterns alone", func() {
+			Expect(getignore.HighlightLine("*.exe")).Should(Equal("*.exe"))
+		})
+	})
+})
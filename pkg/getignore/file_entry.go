@@ -0,0 +1,71 @@
+package getignore
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// FileEntry describes a gitignore patterns file available from a source
+type FileEntry struct {
+	Path string
+	SHA  string
+	Size int
+}
+
+// Category returns the top-level directory containing the file, e.g.,
+// "Global" for "Global/Vim.gitignore", or an empty string for a file at the
+// top level
+func (e FileEntry) Category() string {
+	i := strings.Index(e.Path, "/")
+	if i < 0 {
+		return ""
+	}
+	return e.Path[:i]
+}
+
+// FilterEntries returns the entries matching any of the patterns, if given,
+// and in the category, if given, ignoring case
+func FilterEntries(entries []FileEntry, patterns []Pattern, category string) []FileEntry {
+	var filtered []FileEntry
+	for _, entry := range entries {
+		if len(patterns) > 0 && !MatchAny(patterns, entry.Path) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(entry.Category(), category) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// EntryPaths returns the paths of the entries
+func EntryPaths(entries []FileEntry) []string {
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+// GetFilteredContents gets the contents of the files from the source that
+// FilterEntries returns for the patterns and category. If only some of the
+// files can be got, it returns their contents along with the files that
+// failed, rather than losing them.
+func GetFilteredContents(ctx context.Context, source Source, patterns []Pattern, category string) ([]NamedContents, FailedFiles, error) {
+	entries, err := source.ListEntries(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries = FilterEntries(entries, patterns, category)
+	if len(entries) == 0 {
+		return nil, nil, errors.New("no gitignore patterns files match the filters")
+	}
+	contents, err := source.Get(ctx, EntryPaths(entries))
+	var failedFiles FailedFiles
+	if err != nil && (!errors.As(err, &failedFiles) || len(contents) == 0) {
+		return nil, nil, err
+	}
+	return contents, failedFiles, nil
+}
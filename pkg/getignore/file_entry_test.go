@@ -0,0 +1,112 @@
+package getignore_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("FileEntry", func() {
+	Describe("Category", func() {
+		It("should return the top-level directory", func() {
+			entry := getignore.FileEntry{Path: "community/AWS/SAM.gitignore"}
+			Expect(entry.Category()).Should(Equal("community"))
+		})
+
+		It("should return an empty string for top-level files", func() {
+			entry := getignore.FileEntry{Path: "Go.gitignore"}
+			Expect(entry.Category()).Should(BeEmpty())
+		})
+	})
+
+	Describe("FilterEntries", func() {
+		entries := []getignore.FileEntry{
+			{Path: "Go.gitignore"},
+			{Path: "Global/Vim.gitignore"},
+			{Path: "Global/JetBrains.gitignore"},
+			{Path: "community/Golang/Hugo.gitignore"},
+		}
+
+		It("should return all entries without filters", func() {
+			Expect(getignore.FilterEntries(entries, nil, "")).Should(Equal(entries))
+		})
+
+		It("should return entries matching any pattern", func() {
+			patterns, _ := getignore.ParsePatterns([]string{"**/Vim*", "re:Go"})
+			Expect(getignore.EntryPaths(getignore.FilterEntries(entries, patterns, ""))).Should(Equal([]string{
+				"Go.gitignore",
+				"Global/Vim.gitignore",
+				"community/Golang/Hugo.gitignore",
+			}))
+		})
+
+		It("should return entries in the category, ignoring case", func() {
+			Expect(getignore.EntryPaths(getignore.FilterEntries(entries, nil, "global"))).Should(Equal([]string{
+				"Global/Vim.gitignore",
+				"Global/JetBrains.gitignore",
+			}))
+		})
+
+		It("should combine patterns and the category", func() {
+			patterns, _ := getignore.ParsePatterns([]string{"re:Go"})
+			Expect(getignore.EntryPaths(getignore.FilterEntries(entries, patterns, "community"))).Should(Equal([]string{
+				"community/Golang/Hugo.gitignore",
+			}))
+		})
+	})
+
+	Describe("GetFilteredContents", func() {
+		var source *mapSource
+
+		BeforeEach(func() {
+			source = &mapSource{files: map[string]string{
+				"Go.gitignore":               "*.o\n",
+				"Global/Vim.gitignore":       "*.swp\n",
+				"Global/JetBrains.gitignore": ".idea/\n",
+			}}
+		})
+
+		It("should get only the files passing the filters", func() {
+			patterns, _ := getignore.ParsePatterns([]string{"**/Vim*", "Go*"})
+			contents, failedFiles, err := getignore.GetFilteredContents(context.Background(), source, patterns, "global")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(failedFiles).Should(BeEmpty())
+			Expect(contents).Should(Equal([]getignore.NamedContents{{Name: "Global/Vim.gitignore", Contents: "*.swp\n"}}))
+			Expect(source.calls).Should(Equal([][]string{{"Global/Vim.gitignore"}}))
+		})
+
+		It("should keep the contents got when other files fail", func() {
+			source.failed = map[string]bool{"Global/JetBrains.gitignore": true}
+			contents, failedFiles, err := getignore.GetFilteredContents(context.Background(), source, nil, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "Go.gitignore", Contents: "*.o\n"},
+			}))
+			Expect(failedFiles).Should(Equal(getignore.FailedFiles{{Name: "Global/JetBrains.gitignore", Message: "unable to download"}}))
+		})
+
+		It("should fail when no files are got", func() {
+			source.failed = map[string]bool{"Go.gitignore": true}
+			patterns, _ := getignore.ParsePatterns([]string{"Go*"})
+			_, _, err := getignore.GetFilteredContents(context.Background(), source, patterns, "")
+			Expect(err).Should(MatchError(getignore.FailedFiles{{Name: "Go.gitignore", Message: "unable to download"}}))
+		})
+
+		It("should fail when no files pass the filters", func() {
+			_, _, err := getignore.GetFilteredContents(context.Background(), source, nil, "community")
+			Expect(err).Should(MatchError("no gitignore patterns files match the filters"))
+			Expect(source.calls).Should(BeEmpty())
+		})
+
+		It("should return errors listing the files", func() {
+			source.err = errors.New("unable to list")
+			_, _, err := getignore.GetFilteredContents(context.Background(), source, nil, "")
+			Expect(err).Should(MatchError("unable to list"))
+		})
+	})
+})
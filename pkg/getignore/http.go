@@ -0,0 +1,36 @@
+package getignore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPGet requests the URL with the client, identifying getignore by its
+// user agent, after calling prepare, if given, to set any other headers of
+// the request, e.g., to authenticate. It returns the body and the headers of
+// the response, failing unless its status is 200 OK.
+func HTTPGet(ctx context.Context, client *http.Client, u string, prepare func(req *http.Request)) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", UserAgentString)
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return body, resp.Header, nil
+}
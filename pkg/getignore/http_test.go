@@ -0,0 +1,48 @@
+package getignore_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("HTTPGet", func() {
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return the body and headers of the response", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/templates/Go.gitignore"),
+			ghttp.VerifyHeader(http.Header{
+				"User-Agent":    []string{getignore.UserAgentString},
+				"Authorization": []string{"Bearer secret"},
+			}),
+			ghttp.RespondWith(http.StatusOK, "*.o\n", http.Header{"X-Next-Page": {"2"}}),
+		))
+		body, header, err := getignore.HTTPGet(context.Background(), http.DefaultClient, server.URL()+"/templates/Go.gitignore", func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer secret")
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(body)).Should(Equal("*.o\n"))
+		Expect(header.Get("X-Next-Page")).Should(Equal("2"))
+	})
+
+	It("should fail for other statuses, without revealing credentials in the URL", func() {
+		server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, "not found"))
+		u := "http://user:secret@" + server.Addr() + "/templates/Go.gitignore"
+		_, _, err := getignore.HTTPGet(context.Background(), http.DefaultClient, u, nil)
+		Expect(err).Should(MatchError("GET http://user:xxxxx@" + server.Addr() + "/templates/Go.gitignore: 404 Not Found"))
+	})
+})
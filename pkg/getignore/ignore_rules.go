@@ -0,0 +1,200 @@
+package getignore
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreRule represents a pattern on a line of a gitignore file
+type IgnoreRule struct {
+	Pattern    string
+	LineNumber int
+	Negate     bool
+	DirOnly    bool
+	regexp     *regexp.Regexp
+}
+
+// IgnoreRules represents the patterns of a gitignore file, in order
+type IgnoreRules []IgnoreRule
+
+// ParseIgnoreRules parses the patterns in the contents of a gitignore file,
+// skipping blank lines and comments
+func ParseIgnoreRules(contents string) IgnoreRules {
+	var rules IgnoreRules
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		rule, ok := parseIgnoreRule(line)
+		if !ok {
+			continue
+		}
+		rule.LineNumber = i + 1
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func parseIgnoreRule(line string) (IgnoreRule, bool) {
+	rule := IgnoreRule{Pattern: line}
+	pattern := trimUnescapedTrailingSpaces(line)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return IgnoreRule{}, false
+	}
+	if strings.HasPrefix(pattern, "!") {
+		rule.Negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\!`) || strings.HasPrefix(pattern, `\#`) {
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		rule.DirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if pattern == "" {
+		return IgnoreRule{}, false
+	}
+	// A slash at the beginning or in the middle anchors the pattern to the
+	// top-level directory; otherwise it may match at any level.
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	expr := ignoreGlobToRegexp(pattern)
+	if !anchored && !strings.HasPrefix(expr, "(.*/)?") {
+		expr = "(.*/)?" + expr
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return IgnoreRule{}, false
+	}
+	rule.regexp = re
+	return rule, true
+}
+
+// Match reports whether the rule matches the path, relative to the
+// directory containing the gitignore file
+func (r IgnoreRule) Match(path string, isDir bool) bool {
+	if r.DirOnly && !isDir {
+		return false
+	}
+	return r.regexp.MatchString(path)
+}
+
+// Match reports whether the path would be ignored by the rules, along with
+// the rule deciding so. A path is ignored if the last rule matching it, or
+// any of its parent directories, is not negated; as in git, a file cannot be
+// re-included if a parent directory is ignored. A trailing slash on the path
+// indicates a directory.
+func (rules IgnoreRules) Match(path string) (IgnoreRule, bool) {
+	path, isDir := normalizeIgnorePath(path)
+	if path == "" {
+		return IgnoreRule{}, false
+	}
+	components := strings.Split(path, "/")
+	var (
+		decidingRule IgnoreRule
+		matched      bool
+	)
+	for i := range components {
+		subpath := strings.Join(components[:i+1], "/")
+		last := i == len(components)-1
+		decidingRule, matched = rules.lastMatch(subpath, isDir || !last)
+		if matched && !decidingRule.Negate && !last {
+			return decidingRule, true
+		}
+	}
+	return decidingRule, matched && !decidingRule.Negate
+}
+
+func (rules IgnoreRules) lastMatch(path string, isDir bool) (IgnoreRule, bool) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Match(path, isDir) {
+			return rules[i], true
+		}
+	}
+	return IgnoreRule{}, false
+}
+
+// Which returns the lines of the contents with the rules that would ignore
+// the path, one for each of the contents ignoring it
+func Which(allContents []NamedContents, path string) []SearchResult {
+	var results []SearchResult
+	for _, nc := range allContents {
+		if rule, ignored := ParseIgnoreRules(nc.Contents).Match(path); ignored {
+			results = append(results, SearchResult{Name: nc.Name, LineNumber: rule.LineNumber, Line: rule.Pattern})
+		}
+	}
+	return results
+}
+
+func normalizeIgnorePath(path string) (string, bool) {
+	path = filepath.ToSlash(path)
+	isDir := strings.HasSuffix(path, "/")
+	path = strings.Trim(path, "/")
+	for strings.HasPrefix(path, "./") {
+		path = strings.TrimPrefix(path, "./")
+	}
+	if path == "." {
+		path = ""
+	}
+	return path, isDir
+}
+
+func trimUnescapedTrailingSpaces(line string) string {
+	trimmed := strings.TrimRight(line, " ")
+	if strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(line) {
+		trimmed += " "
+	}
+	return trimmed
+}
+
+// ignoreGlobToRegexp translates a gitignore pattern to a regular expression.
+// Unlike Pattern globs, "**" is only special when it makes up a whole path
+// component: a leading "**/" and an inner "/**/" match zero or more
+// directories, and a trailing "/**" matches everything inside.
+func ignoreGlobToRegexp(glob string) string {
+	var b strings.Builder
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			atStart := i == 0 || runes[i-1] == '/'
+			if i+1 < len(runes) && runes[i+1] == '*' && atStart {
+				switch {
+				case i+2 == len(runes):
+					b.WriteString(".*")
+					i++
+					continue
+				case runes[i+2] == '/':
+					b.WriteString("(.*/)?")
+					i += 2
+					continue
+				}
+			}
+			for i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := indexRune(runes, ']', i+2)
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = end
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package getignore_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("IgnoreRules", func() {
+	rules := getignore.ParseIgnoreRules(`# comment
+*.o
+!keep.o
+/root-only.txt
+build/
+doc/*.txt
+**/logs
+foo/**/bar
+abc/**
+\#hash
+\!bang
+` + "trail\\ \n" + `*.tfstate
+.terraform/
+[Dd]ebug/
+x?z
+a/*/c
+`)
+
+	assertIgnoredBy := func(path string, expectedLineNumber int) {
+		rule, ignored := rules.Match(path)
+		Expect(ignored).Should(BeTrue(), path)
+		Expect(rule.LineNumber).Should(Equal(expectedLineNumber), path)
+	}
+
+	assertNotIgnored := func(path string) {
+		_, ignored := rules.Match(path)
+		Expect(ignored).Should(BeFalse(), path)
+	}
+
+	It("should skip comments and blank lines", func() {
+		Expect(rules).Should(HaveLen(16))
+		Expect(rules[0].Pattern).Should(Equal("*.o"))
+		Expect(rules[0].LineNumber).Should(Equal(2))
+	})
+
+	It("should match patterns without a slash at any level", func() {
+		assertIgnoredBy("main.o", 2)
+		assertIgnoredBy("src/main.o", 2)
+		assertIgnoredBy("x.tfstate", 13)
+	})
+
+	It("should re-include negated paths", func() {
+		assertNotIgnored("keep.o")
+		assertNotIgnored("sub/keep.o")
+		rule, _ := rules.Match("keep.o")
+		Expect(rule.Negate).Should(BeTrue())
+	})
+
+	It("should anchor patterns with a leading slash", func() {
+		assertIgnoredBy("root-only.txt", 4)
+		assertNotIgnored("sub/root-only.txt")
+	})
+
+	It("should anchor patterns with a slash in the middle", func() {
+		assertIgnoredBy("doc/a.txt", 6)
+		assertNotIgnored("doc/sub/a.txt")
+		assertIgnoredBy("a/b/c", 17)
+		assertNotIgnored("a/b/d/c")
+	})
+
+	It("should match directory patterns only to directories", func() {
+		assertIgnoredBy("build/", 5)
+		assertNotIgnored("build")
+	})
+
+	It("should ignore everything in an ignored directory", func() {
+		assertIgnoredBy("build/output.o", 5)
+		assertIgnoredBy("sub/build/x", 5)
+		assertIgnoredBy(".terraform/plugins", 14)
+		assertIgnoredBy("a/.terraform/", 14)
+	})
+
+	It("should match double stars", func() {
+		assertIgnoredBy("logs", 7)
+		assertIgnoredBy("x/logs", 7)
+		assertIgnoredBy("x/logs/y", 7)
+		assertIgnoredBy("foo/bar", 8)
+		assertIgnoredBy("foo/a/b/bar", 8)
+		assertNotIgnored("foo/xbar")
+		assertIgnoredBy("abc/x", 9)
+		assertNotIgnored("abc")
+	})
+
+	It("should match escaped characters literally", func() {
+		assertIgnoredBy("#hash", 10)
+		assertIgnoredBy("!bang", 11)
+		assertIgnoredBy("trail ", 12)
+		assertNotIgnored("trail")
+	})
+
+	It("should match wildcards and character classes", func() {
+		assertIgnoredBy("Debug/x", 15)
+		assertIgnoredBy("debug/x", 15)
+		assertIgnoredBy("xyz", 16)
+		assertNotIgnored("x/z")
+	})
+
+	It("should accept paths relative to the current directory", func() {
+		assertIgnoredBy("./build/output.o", 5)
+	})
+
+	It("should not ignore unmatched paths", func() {
+		assertNotIgnored(".idea/workspace.xml")
+		assertNotIgnored("")
+	})
+
+	Describe("Which", func() {
+		It("should return the lines of the contents ignoring the path", func() {
+			contents := []getignore.NamedContents{
+				{Name: "C.gitignore", Contents: "# Object files\n*.o\n"},
+				{Name: "Java.gitignore", Contents: "*.class\n"},
+				{Name: "Global/JetBrains.gitignore", Contents: ".idea/workspace.xml\n"},
+				{Name: "Kotlin.gitignore", Contents: "*.o\n!output.o\n"},
+			}
+			Expect(getignore.Which(contents, "build/output.o")).Should(Equal([]getignore.SearchResult{
+				{Name: "C.gitignore", LineNumber: 2, Line: "*.o"},
+			}))
+			Expect(getignore.Which(contents, ".idea/workspace.xml")).Should(Equal([]getignore.SearchResult{
+				{Name: "Global/JetBrains.gitignore", LineNumber: 1, Line: ".idea/workspace.xml"},
+			}))
+		})
+
+		It("should evaluate only the filtered files that could be got", func() {
+			source := &mapSource{
+				files: map[string]string{
+					"C.gitignore":                "*.o\n",
+					"Global/Emacs.gitignore":     "*~\n*.o\n",
+					"Global/JetBrains.gitignore": "*.o\n",
+					"Global/Vim.gitignore":       "*.o\n",
+				},
+				failed: map[string]bool{"Global/JetBrains.gitignore": true},
+			}
+			patterns, _ := getignore.ParsePatterns([]string{"**/[EJ]*"})
+			contents, failedFiles, err := getignore.GetFilteredContents(context.Background(), source, patterns, "Global")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(failedFiles).Should(HaveLen(1))
+			Expect(getignore.Which(contents, "main.o")).Should(Equal([]getignore.SearchResult{
+				{Name: "Global/Emacs.gitignore", LineNumber: 2, Line: "*.o"},
+			}))
+		})
+	})
+})
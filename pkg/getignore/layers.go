@@ -0,0 +1,139 @@
+package getignore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LayeredSourceName is the source recorded in banners for files from the
+// layered sources given in the configuration
+const LayeredSourceName = "layers"
+
+// Layer is one of the sources of a LayeredSource
+type Layer struct {
+	// Name identifies the source, as given by SplitSource, and is recorded as
+	// the source of the files from it
+	Name   string
+	Source Source
+}
+
+// LayeredSource combines sources in order of precedence, where a file from a
+// layer shadows the files at the same path in later layers. Names are
+// resolved against the combined files, and each file is got from the first
+// layer that has it.
+type LayeredSource struct {
+	Layers []Layer
+	ResolverSettings
+}
+
+// LayeredEntry describes a file available from a LayeredSource
+type LayeredEntry struct {
+	FileEntry
+	// Layer is the name of the layer the file comes from
+	Layer string
+	// Shadowed are the names of the later layers with a file at the same path
+	Shadowed []string
+}
+
+// List returns the paths of the files from every layer.
+func (s LayeredSource) List(ctx context.Context) ([]string, error) {
+	entries, err := s.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return EntryPaths(entries), nil
+}
+
+// ListEntries returns entries describing the files from every layer, sorted
+// by path, taking each from the first layer that has it.
+func (s LayeredSource) ListEntries(ctx context.Context) ([]FileEntry, error) {
+	layeredEntries, err := s.ListLayeredEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]FileEntry, len(layeredEntries))
+	for i, entry := range layeredEntries {
+		entries[i] = entry.FileEntry
+	}
+	return entries, nil
+}
+
+// ListLayeredEntries returns entries describing the files from every layer,
+// sorted by path, along with the layer each comes from and the layers in
+// which it shadows files.
+func (s LayeredSource) ListLayeredEntries(ctx context.Context) ([]LayeredEntry, error) {
+	var entries []LayeredEntry
+	indices := make(map[string]int)
+	for _, layer := range s.Layers {
+		layerEntries, err := layer.Source.ListEntries(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range layerEntries {
+			if i, ok := indices[entry.Path]; ok {
+				entries[i].Shadowed = append(entries[i].Shadowed, layer.Name)
+				continue
+			}
+			indices[entry.Path] = len(entries)
+			entries = append(entries, LayeredEntry{FileEntry: entry, Layer: layer.Name})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+// Get returns the contents of the files with the given names, each from the
+// first layer that has it, with the name of the layer as its source
+func (s LayeredSource) Get(ctx context.Context, names []string) ([]NamedContents, error) {
+	entries, err := s.ListLayeredEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	layers := make(map[string]string, len(entries))
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		layers[entry.Path] = entry.Layer
+		paths[i] = entry.Path
+	}
+	resolved, failedFiles := s.Resolver().Resolve(names, paths)
+	layerPaths := make(map[string][]string)
+	for _, p := range resolved {
+		layerPaths[layers[p]] = append(layerPaths[layers[p]], p)
+	}
+	contents := make(map[string]NamedContents, len(resolved))
+	for _, layer := range s.Layers {
+		if len(layerPaths[layer.Name]) == 0 {
+			continue
+		}
+		layerContents, err := layer.Source.Get(ctx, layerPaths[layer.Name])
+		if err != nil {
+			return nil, err
+		}
+		for _, nc := range layerContents {
+			nc.Source = layer.Name
+			contents[nc.Name] = nc
+		}
+	}
+	var namedContents []NamedContents
+	for _, p := range resolved {
+		namedContents = append(namedContents, contents[p])
+	}
+	if failedFiles != nil {
+		err = s.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+func (s LayeredSource) newGetError(err error) error {
+	return fmt.Errorf("error getting files from layers %s: %w", strings.Join(s.layerNames(), ", "), err)
+}
+
+func (s LayeredSource) layerNames() []string {
+	names := make([]string, len(s.Layers))
+	for i, layer := range s.Layers {
+		names[i] = layer.Name
+	}
+	return names
+}
@@ -0,0 +1,143 @@
+package getignore_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// mapSource gets files by their exact paths from a map of the paths to their
+// contents, recording the paths it's asked for, and failing to get those
+// marked as failed
+type mapSource struct {
+	files  map[string]string
+	failed map[string]bool
+	calls  [][]string
+	err    error
+}
+
+func (s *mapSource) List(ctx context.Context) ([]string, error) {
+	entries, err := s.ListEntries(ctx)
+	return getignore.EntryPaths(entries), err
+}
+
+func (s *mapSource) ListEntries(context.Context) ([]getignore.FileEntry, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var entries []getignore.FileEntry
+	for p, contents := range s.files {
+		entries = append(entries, getignore.FileEntry{Path: p, SHA: getignore.BlobSHA(contents), Size: len(contents)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+func (s *mapSource) Get(_ context.Context, names []string) ([]getignore.NamedContents, error) {
+	s.calls = append(s.calls, names)
+	var contents []getignore.NamedContents
+	var failedFiles getignore.FailedFiles
+	for _, name := range names {
+		if s.failed[name] {
+			failedFiles = append(failedFiles, getignore.FailedFile{Name: name, Message: "unable to download"})
+			continue
+		}
+		contents = append(contents, getignore.NamedContents{Name: name, Contents: s.files[name]})
+	}
+	if failedFiles != nil {
+		return contents, failedFiles
+	}
+	return contents, nil
+}
+
+var _ = Describe("LayeredSource", func() {
+	var (
+		ctx      context.Context
+		org      *mapSource
+		upstream *mapSource
+		source   getignore.LayeredSource
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		org = &mapSource{files: map[string]string{
+			"Node.gitignore":      "node_modules/\n.env\n",
+			"Terraform.gitignore": ".terraform/\n",
+		}}
+		upstream = &mapSource{files: map[string]string{
+			"Go.gitignore":         "*.o\n",
+			"Node.gitignore":       "node_modules/\n",
+			"Global/Vim.gitignore": "*.swp\n",
+		}}
+		source = getignore.LayeredSource{
+			Layers: []getignore.Layer{
+				{Name: "acme/templates@main", Source: org},
+				{Name: "github/gitignore@master", Source: upstream},
+			},
+			ResolverSettings: getignore.ResolverSettings{Suffix: ".gitignore"},
+		}
+	})
+
+	Describe("ListLayeredEntries", func() {
+		It("should list the files of every layer with the layer each comes from", func() {
+			entries, err := source.ListLayeredEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.LayeredEntry{
+				{FileEntry: getignore.FileEntry{Path: "Global/Vim.gitignore", SHA: getignore.BlobSHA("*.swp\n"), Size: 6}, Layer: "github/gitignore@master"},
+				{FileEntry: getignore.FileEntry{Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n"), Size: 4}, Layer: "github/gitignore@master"},
+				{
+					FileEntry: getignore.FileEntry{Path: "Node.gitignore", SHA: getignore.BlobSHA("node_modules/\n.env\n"), Size: 19},
+					Layer:     "acme/templates@main",
+					Shadowed:  []string{"github/gitignore@master"},
+				},
+				{FileEntry: getignore.FileEntry{Path: "Terraform.gitignore", SHA: getignore.BlobSHA(".terraform/\n"), Size: 12}, Layer: "acme/templates@main"},
+			}))
+		})
+
+		It("should fail when a layer fails", func() {
+			upstream.err = errors.New("no network")
+			_, err := source.ListLayeredEntries(ctx)
+			Expect(err).Should(MatchError("no network"))
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the paths of the files of every layer", func() {
+			Expect(source.List(ctx)).Should(Equal([]string{"Global/Vim.gitignore", "Go.gitignore", "Node.gitignore", "Terraform.gitignore"}))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get each file from the first layer that has it, in the order of the names", func() {
+			contents, err := source.Get(ctx, []string{"node", "Go", "Terraform", "vim"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Node.gitignore", Contents: "node_modules/\n.env\n", Source: "acme/templates@main"},
+				{Name: "Go.gitignore", Contents: "*.o\n", Source: "github/gitignore@master"},
+				{Name: "Terraform.gitignore", Contents: ".terraform/\n", Source: "acme/templates@main"},
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n", Source: "github/gitignore@master"},
+			}))
+			Expect(org.calls).Should(Equal([][]string{{"Node.gitignore", "Terraform.gitignore"}}))
+			Expect(upstream.calls).Should(Equal([][]string{{"Go.gitignore", "Global/Vim.gitignore"}}))
+		})
+
+		It("should report names not present in any layer", func() {
+			contents, err := source.Get(ctx, []string{"Go", "Rust"})
+			Expect(err).Should(MatchError("error getting files from layers acme/templates@main, github/gitignore@master: failed to get the following files: Rust\nRust: not present in file tree\n"))
+			Expect(contents).Should(HaveLen(1))
+		})
+
+		It("should keep the layers as the sources of the contents", func() {
+			getter := func(string) (getignore.Getter, error) { return source, nil }
+			contents, err := getignore.GetContents(ctx, getter, []string{"Node", "Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents[0].Source).Should(Equal("acme/templates@main"))
+			Expect(contents[1].Source).Should(Equal("github/gitignore@master"))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// shortSHALength is the number of characters of a SHA shown in tree listings
+const shortSHALength = 7
+
+// WriteListing writes the paths of the entries, one per line. In the long
+// format, each path is preceded by the entry's SHA and size in bytes.
+func WriteListing(listingFile io.Writer, entries []FileEntry, long bool) error {
+	writer := bufio.NewWriter(listingFile)
+	sizeWidth := maxSizeWidth(entries)
+	for _, entry := range entries {
+		if long {
+			fmt.Fprintf(writer, "%s %*d %s\n", entry.SHA, sizeWidth, entry.Size, entry.Path)
+		} else {
+			fmt.Fprintln(writer, entry.Path)
+		}
+	}
+	return writer.Flush()
+}
+
+// WriteLayeredListing writes the paths of the entries, one per line, each
+// followed by the layer it comes from and any layers in which it shadows
+// files. In the long format, each path is preceded by the entry's SHA and
+// size in bytes.
+func WriteLayeredListing(listingFile io.Writer, entries []LayeredEntry, long bool) error {
+	writer := bufio.NewWriter(listingFile)
+	var sizeWidth, pathWidth int
+	for _, entry := range entries {
+		sizeWidth = max(sizeWidth, len(strconv.Itoa(entry.Size)))
+		pathWidth = max(pathWidth, len(entry.Path))
+	}
+	for _, entry := range entries {
+		if long {
+			fmt.Fprintf(writer, "%s %*d ", entry.SHA, sizeWidth, entry.Size)
+		}
+		fmt.Fprintf(writer, "%-*s  %s", pathWidth, entry.Path, entry.Layer)
+		if len(entry.Shadowed) > 0 {
+			fmt.Fprintf(writer, " (shadows %s)", strings.Join(entry.Shadowed, ", "))
+		}
+		fmt.Fprintln(writer)
+	}
+	return writer.Flush()
+}
+
+// WriteTreeListing writes the entries as a tree of directories and files. In
+// the long format, each file is followed by its size in bytes and
+// abbreviated SHA.
+func WriteTreeListing(listingFile io.Writer, entries []FileEntry, long bool) error {
+	writer := bufio.NewWriter(listingFile)
+	root := newListingNode(".", nil)
+	sorted := append([]FileEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	for i := range sorted {
+		root.insert(strings.Split(sorted[i].Path, "/"), &sorted[i])
+	}
+	fmt.Fprintln(writer, root.name)
+	root.writeChildren(writer, "", long)
+	return writer.Flush()
+}
+
+// listingNode is a directory or file in a tree listing
+type listingNode struct {
+	name     string
+	entry    *FileEntry
+	children []*listingNode
+	index    map[string]*listingNode
+}
+
+func newListingNode(name string, entry *FileEntry) *listingNode {
+	return &listingNode{name: name, entry: entry, index: make(map[string]*listingNode)}
+}
+
+func (n *listingNode) insert(parts []string, entry *FileEntry) {
+	name := parts[0]
+	if len(parts) == 1 {
+		n.children = append(n.children, newListingNode(name, entry))
+		return
+	}
+	child, ok := n.index[name]
+	if !ok {
+		child = newListingNode(name, nil)
+		n.index[name] = child
+		n.children = append(n.children, child)
+	}
+	child.insert(parts[1:], entry)
+}
+
+func (n *listingNode) writeChildren(writer *bufio.Writer, prefix string, long bool) {
+	for i, child := range n.children {
+		branch, indent := "├── ", "│   "
+		if i == len(n.children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		writer.WriteString(prefix + branch + child.name)
+		if long && child.entry != nil {
+			fmt.Fprintf(writer, " (%d bytes, %s)", child.entry.Size, shortSHA(child.entry.SHA))
+		}
+		writer.WriteString("\n")
+		child.writeChildren(writer, prefix+indent, long)
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+func maxSizeWidth(entries []FileEntry) int {
+	width := 0
+	for _, entry := range entries {
+		width = max(width, len(strconv.Itoa(entry.Size)))
+	}
+	return width
+}
@@ -0,0 +1,96 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Listing", func() {
+	var (
+		outputFile *bytes.Buffer
+		entries    = []getignore.FileEntry{
+			{Path: "Actionscript.gitignore", SHA: "5d947ca8879f8a9072fe485c566204e3c2929e80", Size: 350},
+			{Path: "Global/Anjuta.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4", Size: 78},
+			{Path: "Global/Vim.gitignore", SHA: "8ce8f1fe6fbd2c8bd6bb4e8ab5d5e4c5f1ac6b2f", Size: 1203},
+			{Path: "community/AWS/SAM.gitignore", SHA: "dc9d020aee1ebc1a23c02d80a1c33c0cb35ebaeb", Size: 167},
+		}
+	)
+
+	BeforeEach(func() {
+		outputFile = bytes.NewBufferString("")
+	})
+
+	Describe("WriteListing", func() {
+		It("should write one path per line", func() {
+			getignore.WriteListing(outputFile, entries, false)
+			Expect(outputFile.String()).Should(Equal(`Actionscript.gitignore
+Global/Anjuta.gitignore
+Global/Vim.gitignore
+community/AWS/SAM.gitignore
+`))
+		})
+
+		It("should write the SHA and aligned size in the long format", func() {
+			getignore.WriteListing(outputFile, entries, true)
+			Expect(outputFile.String()).Should(Equal(`5d947ca8879f8a9072fe485c566204e3c2929e80  350 Actionscript.gitignore
+20dd42c53e6f0df8233fee457b664d443ee729f4   78 Global/Anjuta.gitignore
+8ce8f1fe6fbd2c8bd6bb4e8ab5d5e4c5f1ac6b2f 1203 Global/Vim.gitignore
+dc9d020aee1ebc1a23c02d80a1c33c0cb35ebaeb  167 community/AWS/SAM.gitignore
+`))
+		})
+
+		It("should write nothing without entries", func() {
+			getignore.WriteListing(outputFile, nil, false)
+			Expect(outputFile.String()).Should(BeEmpty())
+		})
+	})
+
+	Describe("WriteLayeredListing", func() {
+		layeredEntries := []getignore.LayeredEntry{
+			{FileEntry: entries[1], Layer: "github/gitignore@master"},
+			{FileEntry: entries[0], Layer: "acme/templates@main", Shadowed: []string{"github/gitignore@master"}},
+		}
+
+		It("should write each path with the layer it comes from", func() {
+			getignore.WriteLayeredListing(outputFile, layeredEntries, false)
+			Expect(outputFile.String()).Should(Equal(`Global/Anjuta.gitignore  github/gitignore@master
+Actionscript.gitignore   acme/templates@main (shadows github/gitignore@master)
+`))
+		})
+
+		It("should write the SHA and aligned size in the long format", func() {
+			getignore.WriteLayeredListing(outputFile, layeredEntries, true)
+			Expect(outputFile.String()).Should(Equal(`20dd42c53e6f0df8233fee457b664d443ee729f4  78 Global/Anjuta.gitignore  github/gitignore@master
+5d947ca8879f8a9072fe485c566204e3c2929e80 350 Actionscript.gitignore   acme/templates@main (shadows github/gitignore@master)
+`))
+		})
+	})
+
+	Describe("WriteTreeListing", func() {
+		It("should write the entries as a tree", func() {
+			getignore.WriteTreeListing(outputFile, entries, false)
+			Expect(outputFile.String()).Should(Equal(`.
+├── Actionscript.gitignore
+├── Global
+│   ├── Anjuta.gitignore
+│   └── Vim.gitignore
+└── community
+    └── AWS
+        └── SAM.gitignore
+`))
+		})
+
+		It("should write sizes and abbreviated SHAs in the long format", func() {
+			getignore.WriteTreeListing(outputFile, entries[:2], true)
+			Expect(outputFile.String()).Should(Equal(`.
+├── Actionscript.gitignore (350 bytes, 5d947ca)
+└── Global
+    └── Anjuta.gitignore (78 bytes, 20dd42c)
+`))
+		})
+	})
+})
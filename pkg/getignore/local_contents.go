@@ -0,0 +1,230 @@
+package getignore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFilePrefix marks a name as the path of a local file
+const LocalFilePrefix = "file:"
+
+// LocalSource is the source of contents read from local files
+const LocalSource = "file"
+
+// InlinePrefix marks a name as a pattern given inline, e.g., add:tmp/
+const InlinePrefix = "add:"
+
+// InlineContentsName is the name of the section for patterns given inline
+const InlineContentsName = "Custom"
+
+// Getter gets the contents of gitignore patterns files by name from a source
+type Getter interface {
+	Get(ctx context.Context, names []string) ([]NamedContents, error)
+}
+
+// IsLocalName reports whether a name refers to a local file rather than a
+// file from a source: it either has the LocalFilePrefix, or is an absolute
+// path or a path relative to the current directory, e.g., ./extra.gitignore
+func IsLocalName(name string) bool {
+	return strings.HasPrefix(name, LocalFilePrefix) ||
+		filepath.IsAbs(name) ||
+		strings.HasPrefix(name, "./") ||
+		strings.HasPrefix(name, "../") ||
+		strings.HasPrefix(name, "."+string(filepath.Separator)) ||
+		strings.HasPrefix(name, ".."+string(filepath.Separator))
+}
+
+// IsInlineName reports whether a name is a pattern given inline, with the
+// InlinePrefix
+func IsInlineName(name string) bool {
+	return strings.HasPrefix(name, InlinePrefix)
+}
+
+// InlineName returns the name for a pattern given inline
+func InlineName(pattern string) string {
+	return InlinePrefix + pattern
+}
+
+// ReadLocalFile reads the contents of the local file the name refers to
+func ReadLocalFile(name string) (NamedContents, error) {
+	path := strings.TrimPrefix(name, LocalFilePrefix)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return NamedContents{}, FailedFile{
+			Name:    path,
+			Message: "unable to read local file",
+			Err:     err,
+		}
+	}
+	return NamedContents{Name: path, Contents: string(contents), Source: LocalSource}, nil
+}
+
+// NewInlineContents returns contents consisting of the given patterns, one
+// per line
+func NewInlineContents(patterns []string) NamedContents {
+	return NamedContents{
+		Name:     InlineContentsName,
+		Contents: strings.Join(patterns, "\n") + "\n",
+	}
+}
+
+// InlineContents returns the contents of the patterns given inline among the
+// names, with one section for each run of them, in order
+func InlineContents(names []string) []NamedContents {
+	var contents []NamedContents
+	var patterns []string
+	for _, name := range names {
+		if IsInlineName(name) {
+			patterns = append(patterns, strings.TrimPrefix(name, InlinePrefix))
+			continue
+		}
+		if len(patterns) > 0 {
+			contents = append(contents, NewInlineContents(patterns))
+			patterns = nil
+		}
+	}
+	if len(patterns) > 0 {
+		contents = append(contents, NewInlineContents(patterns))
+	}
+	return contents
+}
+
+// GetterFactory returns the Getter for a source, as given by SplitSource;
+// the empty source is the default one
+type GetterFactory func(source string) (Getter, error)
+
+// NameResolver is implemented by getters that resolve names with a
+// Resolver, which tells the files got for each name apart
+type NameResolver interface {
+	Resolver() Resolver
+}
+
+// getterResolver returns the NameResolver of the getter, or of the getter it
+// wraps, as given by its Unwrap method, if any
+func getterResolver(getter Getter) (NameResolver, bool) {
+	for {
+		if nameResolver, ok := getter.(NameResolver); ok {
+			return nameResolver, true
+		}
+		wrapper, ok := getter.(interface{ Unwrap() Getter })
+		if !ok {
+			return nil, false
+		}
+		getter = wrapper.Unwrap()
+	}
+}
+
+// GetContents gets the contents for the names in order, reading names for
+// which IsLocalName is true from local files, putting each run of names for
+// which IsInlineName is true in a section of their patterns, as
+// InlineContents does, and getting all the other names
+// from the same source together, with one Get from the getter for the
+// source. Contents are attributed to their source, unless the getter
+// attributes them to another, as a LayeredSource does to its layers.
+//
+// The contents got for each name are put in its place among the local files
+// when the getter, or the one it wraps, is a NameResolver; otherwise, all the
+// contents from a source take the place of its first name.
+func GetContents(ctx context.Context, newGetter GetterFactory, names []string) ([]NamedContents, error) {
+	var sources []string
+	sourceNames := make(map[string][]string)
+	for _, name := range names {
+		if IsLocalName(name) || IsInlineName(name) {
+			continue
+		}
+		source, remoteName := SplitSource(name)
+		if _, ok := sourceNames[source]; !ok {
+			sources = append(sources, source)
+		}
+		sourceNames[source] = append(sourceNames[source], remoteName)
+	}
+	// nameContents holds the contents for each remote name, by source, in the
+	// order of the names
+	nameContents := make(map[string][][]NamedContents, len(sources))
+	for _, source := range sources {
+		contents, err := getSourceContents(ctx, newGetter, source, sourceNames[source])
+		if err != nil {
+			return nil, err
+		}
+		nameContents[source] = contents
+	}
+	var allContents []NamedContents
+	inlineContents := InlineContents(names)
+	for i, name := range names {
+		if IsInlineName(name) {
+			if i == len(names)-1 || !IsInlineName(names[i+1]) {
+				allContents = append(allContents, inlineContents[0])
+				inlineContents = inlineContents[1:]
+			}
+			continue
+		}
+		if !IsLocalName(name) {
+			source, _ := SplitSource(name)
+			allContents = append(allContents, nameContents[source][0]...)
+			nameContents[source] = nameContents[source][1:]
+			continue
+		}
+		nc, err := ReadLocalFile(name)
+		if err != nil {
+			return nil, err
+		}
+		allContents = append(allContents, nc)
+	}
+	return allContents, nil
+}
+
+// getSourceContents gets the contents for the names from the source, and
+// returns those for each name in turn
+func getSourceContents(ctx context.Context, newGetter GetterFactory, source string, names []string) ([][]NamedContents, error) {
+	getter, err := newGetter(source)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := getter.Get(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for i := range contents {
+		if contents[i].Source == "" {
+			contents[i].Source = source
+		}
+	}
+	byName := make([][]NamedContents, len(names))
+	nameResolver, ok := getterResolver(getter)
+	if !ok {
+		byName[0] = contents
+		return byName, nil
+	}
+	// Resolving against only the paths got finds the same path for each name,
+	// which is only got for the first name resolving to it. Exclusions are
+	// already left out.
+	resolver := nameResolver.Resolver()
+	resolver.Excludes = nil
+	resolver.ExcludeNames = nil
+	paths := make([]string, len(contents))
+	indices := make(map[string]int, len(contents))
+	for i, nc := range contents {
+		paths[i] = nc.Name
+		indices[nc.Name] = i
+	}
+	placed := make(map[string]bool, len(contents))
+	for i, name := range names {
+		resolved, _ := resolver.Resolve([]string{name}, paths)
+		for _, p := range resolved {
+			if !placed[p] {
+				placed[p] = true
+				byName[i] = append(byName[i], contents[indices[p]])
+			}
+		}
+	}
+	// Put any contents no name resolves to in the place of the first name,
+	// rather than losing them
+	for _, nc := range contents {
+		if !placed[nc.Name] {
+			byName[0] = append(byName[0], nc)
+		}
+	}
+	return byName, nil
+}
@@ -0,0 +1,283 @@
+package getignore_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// fakeGetter resolves names against a fixed set of paths, recording the
+// names it is asked for
+type fakeGetter struct {
+	calls [][]string
+	err   error
+}
+
+var fakePaths = []string{"Ansible.gitignore", "Global/Emacs.gitignore", "Global/Vim.gitignore", "Go.gitignore", "Java.gitignore", "Node.gitignore", "Terraform.gitignore"}
+
+func (g *fakeGetter) factory(source string) (getignore.Getter, error) {
+	if source != "" {
+		return &sourceGetter{source: source, getter: g}, nil
+	}
+	return g, nil
+}
+
+func (g *fakeGetter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	g.calls = append(g.calls, names)
+	return g.get(names)
+}
+
+func (g *fakeGetter) get(names []string) ([]getignore.NamedContents, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	paths, failedFiles := g.Resolver().Resolve(names, fakePaths)
+	if failedFiles != nil {
+		return nil, failedFiles
+	}
+	var contents []getignore.NamedContents
+	for _, p := range paths {
+		contents = append(contents, getignore.NamedContents{Name: p, Contents: strings.TrimSuffix(p, ".gitignore") + "\n"})
+	}
+	return contents, nil
+}
+
+func (g *fakeGetter) Resolver() getignore.Resolver {
+	return getignore.Resolver{Suffix: ".gitignore"}
+}
+
+// sourceGetter records the names it gets qualified with its source, and
+// wraps the getter
+type sourceGetter struct {
+	source string
+	getter *fakeGetter
+}
+
+func (g *sourceGetter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	qualified := make([]string, len(names))
+	for i, name := range names {
+		qualified[i] = g.source + ":" + name
+	}
+	g.getter.calls = append(g.getter.calls, qualified)
+	return g.getter.get(names)
+}
+
+func (g *sourceGetter) Unwrap() getignore.Getter {
+	return g.getter
+}
+
+// plainGetter gets contents without resolving names with a Resolver
+type plainGetter struct {
+	getter *fakeGetter
+}
+
+func (g plainGetter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	return g.getter.Get(ctx, names)
+}
+
+var _ = Describe("Local contents", func() {
+	Describe("IsLocalName", func() {
+		It("should treat relative and absolute paths as local", func() {
+			Expect(getignore.IsLocalName("./extra.gitignore")).Should(BeTrue())
+			Expect(getignore.IsLocalName("../extra.gitignore")).Should(BeTrue())
+			Expect(getignore.IsLocalName("/tmp/extra.gitignore")).Should(BeTrue())
+		})
+
+		It("should treat names with the file prefix as local", func() {
+			Expect(getignore.IsLocalName("file:extra.gitignore")).Should(BeTrue())
+		})
+
+		It("should not treat names and patterns as local", func() {
+			Expect(getignore.IsLocalName("Go")).Should(BeFalse())
+			Expect(getignore.IsLocalName("Global/Vim")).Should(BeFalse())
+			Expect(getignore.IsLocalName("Global/*")).Should(BeFalse())
+		})
+	})
+
+	Describe("NewInlineContents", func() {
+		It("should put each pattern on its own line", func() {
+			Expect(getignore.NewInlineContents([]string{"tmp/", "!tmp/.keep"})).Should(Equal(getignore.NamedContents{
+				Name:     "Custom",
+				Contents: "tmp/\n!tmp/.keep\n",
+			}))
+		})
+	})
+
+	Describe("IsInlineName", func() {
+		It("should treat names with the inline prefix as inline", func() {
+			Expect(getignore.IsInlineName("add:tmp/")).Should(BeTrue())
+			Expect(getignore.IsInlineName(getignore.InlineName("!tmp/.keep"))).Should(BeTrue())
+		})
+
+		It("should not treat names, patterns, and local files as inline", func() {
+			Expect(getignore.IsInlineName("Go")).Should(BeFalse())
+			Expect(getignore.IsInlineName("Global/*")).Should(BeFalse())
+			Expect(getignore.IsInlineName("file:add.gitignore")).Should(BeFalse())
+		})
+	})
+
+	Describe("InlineContents", func() {
+		It("should put each run of patterns given inline in its own section", func() {
+			Expect(getignore.InlineContents([]string{"add:*.log", "Go", "add:tmp/", "add:!tmp/.keep", "Java"})).Should(Equal([]getignore.NamedContents{
+				{Name: "Custom", Contents: "*.log\n"},
+				{Name: "Custom", Contents: "tmp/\n!tmp/.keep\n"},
+			}))
+		})
+
+		It("should return nothing without patterns given inline", func() {
+			Expect(getignore.InlineContents([]string{"Go", "Java"})).Should(BeEmpty())
+		})
+	})
+
+	Describe("GetContents", func() {
+		var (
+			dir    string
+			getter *fakeGetter
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "getignore")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(dir, "extra.gitignore"), []byte("extra/\n"), 0o644)).Should(Succeed())
+			getter = &fakeGetter{}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should keep the requested order of remote names and local files", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{"Go", "Node", localPath, "Java"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: "Node.gitignore", Contents: "Node\n"},
+				{Name: localPath, Contents: "extra/\n", Source: "file"},
+				{Name: "Java.gitignore", Contents: "Java\n"},
+			}))
+			Expect(getter.calls).Should(Equal([][]string{{"Go", "Node", "Java"}}))
+		})
+
+		It("should put patterns given inline in their place among the names", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{"add:*.log", "Go", "add:tmp/", "add:!tmp/.keep", localPath, "Java", "add:*.bak"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Custom", Contents: "*.log\n"},
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: "Custom", Contents: "tmp/\n!tmp/.keep\n"},
+				{Name: localPath, Contents: "extra/\n", Source: "file"},
+				{Name: "Java.gitignore", Contents: "Java\n"},
+				{Name: "Custom", Contents: "*.bak\n"},
+			}))
+			Expect(getter.calls).Should(Equal([][]string{{"Go", "Java"}}))
+		})
+
+		It("should get all the names from the same source together", func() {
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{
+				"Go", "acme/templates@v2:Terraform", "Node", "acme/templates@v2:Ansible",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: "Terraform.gitignore", Contents: "Terraform\n", Source: "acme/templates@v2"},
+				{Name: "Node.gitignore", Contents: "Node\n"},
+				{Name: "Ansible.gitignore", Contents: "Ansible\n", Source: "acme/templates@v2"},
+			}))
+			Expect(getter.calls).Should(Equal([][]string{
+				{"Go", "Node"},
+				{"acme/templates@v2:Terraform", "acme/templates@v2:Ansible"},
+			}))
+		})
+
+		It("should get names qualified by sources with prefixes from those sources", func() {
+			sources := []string{
+				"git+file:///srv/git/templates#main",
+				"index+https://artifacts.example.com:8443/gitignore/index.json",
+				"bundle+/opt/getignore/templates.tar.gz",
+				"gitignoreio+https://www.toptal.com/developers/gitignore",
+			}
+			names := []string{"Go"}
+			for _, source := range sources {
+				names = append(names, source+":Node")
+			}
+			contents, err := getignore.GetContents(context.Background(), getter.factory, names)
+			Expect(err).ShouldNot(HaveOccurred())
+			expected := []getignore.NamedContents{{Name: "Go.gitignore", Contents: "Go\n"}}
+			expectedCalls := [][]string{{"Go"}}
+			for _, source := range sources {
+				expected = append(expected, getignore.NamedContents{Name: "Node.gitignore", Contents: "Node\n", Source: source})
+				expectedCalls = append(expectedCalls, []string{source + ":Node"})
+			}
+			Expect(contents).Should(Equal(expected))
+			Expect(getter.calls).Should(Equal(expectedCalls))
+		})
+
+		It("should put the files a pattern matches in its place", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{"Go", localPath, "Global/*", "Node"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: localPath, Contents: "extra/\n", Source: "file"},
+				{Name: "Global/Emacs.gitignore", Contents: "Global/Emacs\n"},
+				{Name: "Global/Vim.gitignore", Contents: "Global/Vim\n"},
+				{Name: "Node.gitignore", Contents: "Node\n"},
+			}))
+		})
+
+		It("should get files only once across local files", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{"Go", localPath, "go", "Node"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: localPath, Contents: "extra/\n", Source: "file"},
+				{Name: "Node.gitignore", Contents: "Node\n"},
+			}))
+		})
+
+		It("should put all the contents from a getter without a resolver in place of its first name", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			factory := func(string) (getignore.Getter, error) { return plainGetter{getter: getter}, nil }
+			contents, err := getignore.GetContents(context.Background(), factory, []string{"Go", localPath, "Node"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "Go\n"},
+				{Name: "Node.gitignore", Contents: "Node\n"},
+				{Name: localPath, Contents: "extra/\n", Source: "file"},
+			}))
+			Expect(getter.calls).Should(Equal([][]string{{"Go", "Node"}}))
+		})
+
+		It("should read files with the file prefix", func() {
+			localPath := filepath.Join(dir, "extra.gitignore")
+			contents, err := getignore.GetContents(context.Background(), getter.factory, []string{"file:" + localPath})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{{Name: localPath, Contents: "extra/\n", Source: "file"}}))
+			Expect(getter.calls).Should(BeEmpty())
+		})
+
+		It("should fail for missing local files", func() {
+			localPath := filepath.Join(dir, "missing.gitignore")
+			_, err := getignore.GetContents(context.Background(), getter.factory, []string{localPath})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(HavePrefix("failed to get " + localPath + ": unable to read local file"))
+		})
+
+		It("should return errors from the getter", func() {
+			getter.err = errors.New("boom")
+			_, err := getignore.GetContents(context.Background(), getter.factory, []string{"Go"})
+			Expect(err).Should(MatchError("boom"))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package getignore
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LockFileSuffix is appended to the path of a gitignore file to name its
+// lock file
+const LockFileSuffix = ".lock.json"
+
+// Lock records exactly what the managed section of a gitignore file was
+// generated from
+type Lock struct {
+	// Names are all the names requested, including those from the names file
+	Names []string `json:"names"`
+	// Exclusions are the names and patterns excluded by the names file
+	Exclusions []string `json:"exclusions,omitempty"`
+	// Files are the gitignore patterns files of the sections, in order
+	Files []LockedFile `json:"files"`
+}
+
+// LockedFile records the version of a gitignore patterns file a section was
+// generated from
+type LockedFile struct {
+	// Source is where the file came from, e.g., github/gitignore@main, or
+	// LocalSource for local files
+	Source string `json:"source"`
+	// Path is the path of the file within the source
+	Path string `json:"path"`
+	// SHA is the Git blob SHA of the contents of the file
+	SHA string `json:"sha"`
+}
+
+// BlobSHA returns the SHA Git uses to identify a blob with the contents
+func BlobSHA(contents string) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00", len(contents))
+	io.WriteString(hash, contents)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// NewLock records the names and the contents retrieved for them, using the
+// default source for contents without one; contents given inline are left
+// out, as they are recorded by the banner
+func NewLock(namesList NamesList, allContents []NamedContents, defaultSource string) Lock {
+	lock := Lock{Names: namesList.Names, Exclusions: namesList.Exclusions}
+	for _, nc := range allContents {
+		if nc.Name == InlineContentsName {
+			continue
+		}
+		source := nc.Source
+		if source == "" {
+			source = defaultSource
+		}
+		lock.Files = append(lock.Files, LockedFile{
+			Source: source,
+			Path:   nc.Name,
+			SHA:    BlobSHA(nc.Contents),
+		})
+	}
+	return lock
+}
+
+// ParseLock reads a lock file
+func ParseLock(lockFile io.Reader) (Lock, error) {
+	var lock Lock
+	if err := json.NewDecoder(lockFile).Decode(&lock); err != nil {
+		return Lock{}, fmt.Errorf("invalid lock file: %w", err)
+	}
+	return lock, nil
+}
+
+// ReadLock reads the lock file at the path
+func ReadLock(path string) (Lock, error) {
+	lockFile, err := os.Open(path)
+	if err != nil {
+		return Lock{}, fmt.Errorf("unable to read lock file: %w", err)
+	}
+	defer lockFile.Close()
+	return ParseLock(lockFile)
+}
+
+// SectionFiles returns the locked file of each section, given the names of
+// the sections in order, or a zero LockedFile for sections not in the lock.
+// As section headers record only the base names of files, each section is
+// matched with the first locked file not yet matched with the same name, so
+// that files with the same base name, e.g., Global/Vim.gitignore and
+// community/Vim.gitignore, are told apart.
+func (l Lock) SectionFiles(sectionNames []string) []LockedFile {
+	unmatched := make(map[string][]LockedFile, len(l.Files))
+	for _, lf := range l.Files {
+		nc := NamedContents{Name: lf.Path}
+		name := nc.DisplayName()
+		unmatched[name] = append(unmatched[name], lf)
+	}
+	sectionFiles := make([]LockedFile, len(sectionNames))
+	for i, name := range sectionNames {
+		if files := unmatched[name]; len(files) > 0 {
+			sectionFiles[i], unmatched[name] = files[0], files[1:]
+		}
+	}
+	return sectionFiles
+}
+
+// WriteLock writes the lock as indented JSON
+func WriteLock(lockFile io.Writer, lock Lock) error {
+	encoder := json.NewEncoder(lockFile)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(lock)
+}
@@ -0,0 +1,61 @@
+package getignore_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Lock", func() {
+	It("should compute Git blob SHAs", func() {
+		// git hash-object of a file containing "*.o\n"
+		Expect(getignore.BlobSHA("*.o\n")).Should(Equal("5761abcfdf0c26a75374c945dfe366eaeee04285"))
+	})
+
+	It("should record the names and files, leaving out inline contents", func() {
+		namesList := getignore.NamesList{Names: []string{"Go", "acme/templates:Terraform", "./extra.gitignore"}, Exclusions: []string{"Global/Vim"}}
+		lock := getignore.NewLock(namesList, []getignore.NamedContents{
+			{Name: "Go.gitignore", Contents: "*.o\n"},
+			{Name: "Terraform.gitignore", Contents: "*.tfstate\n", Source: "acme/templates"},
+			{Name: "./extra.gitignore", Contents: "extra/\n", Source: getignore.LocalSource},
+			getignore.NewInlineContents([]string{"tmp/"}),
+		}, "github/gitignore@main")
+		Expect(lock.Names).Should(Equal(namesList.Names))
+		Expect(lock.Exclusions).Should(Equal(namesList.Exclusions))
+		Expect(lock.Files).Should(Equal([]getignore.LockedFile{
+			{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n")},
+			{Source: "acme/templates", Path: "Terraform.gitignore", SHA: getignore.BlobSHA("*.tfstate\n")},
+			{Source: "file", Path: "./extra.gitignore", SHA: getignore.BlobSHA("extra/\n")},
+		}))
+	})
+
+	It("should read the lock it writes", func() {
+		lock := getignore.Lock{
+			Names: []string{"Go"},
+			Files: []getignore.LockedFile{{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: "abc"}},
+		}
+		lockFile := bytes.NewBufferString("")
+		Expect(getignore.WriteLock(lockFile, lock)).Should(Succeed())
+		Expect(getignore.ParseLock(lockFile)).Should(Equal(lock))
+	})
+
+	It("should match sections with the locked files of the same name, in order", func() {
+		lock := getignore.Lock{Files: []getignore.LockedFile{
+			{Source: "github/gitignore@main", Path: "Global/Vim.gitignore", SHA: "abc"},
+			{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: "def"},
+			{Source: "github/gitignore@main", Path: "community/Vim.gitignore", SHA: "123"},
+		}}
+		Expect(lock.SectionFiles([]string{"Vim", "Go", "Vim", "Custom"})).Should(Equal([]getignore.LockedFile{
+			lock.Files[0], lock.Files[1], lock.Files[2], {},
+		}))
+	})
+
+	It("should fail for invalid lock files", func() {
+		_, err := getignore.ParseLock(strings.NewReader("{"))
+		Expect(err).Should(MatchError(HavePrefix("invalid lock file:")))
+	})
+})
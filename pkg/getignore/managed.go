@@ -0,0 +1,157 @@
+package getignore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markers delimiting the section of a gitignore file managed by getignore
+const (
+	ManagedBeginMarker = "# BEGIN getignore"
+	ManagedEndMarker   = "# END getignore"
+)
+
+// managedNotice follows the begin marker, warning against editing the
+// managed section by hand
+const managedNotice = "# Managed by getignore; run `getignore update` rather than editing between these markers."
+
+// Keys of the banner lines recording how the managed section was generated
+const (
+	bannerSourceKey    = "source"
+	bannerNamesKey     = "names"
+	bannerNamesFileKey = "names-file"
+	bannerAddKey       = "add"
+)
+
+// Banner records how the managed section of a gitignore file was generated,
+// so that it can be generated again
+type Banner struct {
+	// Source is the default source of the names, e.g., github/gitignore@main
+	Source string
+	// Names are the names given on the command line, including the patterns
+	// given inline, in their places
+	Names []string
+	// NamesFile is the path of the names file, if one was given
+	NamesFile string
+	// Add are the patterns given inline, as recorded by earlier versions,
+	// which put them after all the other sections
+	Add []string
+}
+
+// ManagedFile is a gitignore file containing a section managed by getignore,
+// which is made up of a banner and sections of gitignore patterns files
+type ManagedFile struct {
+	// Before is the text preceding the managed section
+	Before string
+	Banner Banner
+	// Sections are the sections within the managed section
+	Sections []NamedContents
+	// After is the text following the managed section
+	After string
+	// BeginLine is the line number of the begin marker
+	BeginLine int
+	// SectionLines are the line numbers of the names of the sections
+	SectionLines []int
+}
+
+// ParseManagedFile splits the contents of a gitignore file around its
+// managed section, reporting whether it has one
+func ParseManagedFile(contents string) (ManagedFile, bool) {
+	lines := strings.Split(contents, "\n")
+	begin, end := -1, -1
+	for i, line := range lines {
+		if begin < 0 && line == ManagedBeginMarker {
+			begin = i
+		} else if begin >= 0 && line == ManagedEndMarker {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return ManagedFile{}, false
+	}
+	mf := ManagedFile{
+		Before:    joinLines(lines[:begin]),
+		After:     strings.Join(lines[end+1:], "\n"),
+		BeginLine: begin + 1,
+	}
+	inner := lines[begin+1 : end]
+	bannerEnd := 0
+	for bannerEnd < len(inner) && strings.HasPrefix(inner[bannerEnd], "# ") {
+		mf.Banner.parseLine(inner[bannerEnd])
+		bannerEnd++
+	}
+	var headerLines []int
+	_, mf.Sections, headerLines = parseSectionLines(inner[bannerEnd:])
+	for _, headerLine := range headerLines {
+		mf.SectionLines = append(mf.SectionLines, begin+1+bannerEnd+headerLine+1)
+	}
+	return mf, true
+}
+
+// joinLines joins lines, ending each with a newline
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (b *Banner) parseLine(line string) {
+	line = strings.TrimPrefix(line, "# ")
+	i := strings.Index(line, ": ")
+	if i < 0 {
+		return
+	}
+	key, value := line[:i], line[i+2:]
+	switch key {
+	case bannerSourceKey:
+		b.Source = value
+	case bannerNamesKey:
+		json.Unmarshal([]byte(value), &b.Names)
+	case bannerNamesFileKey:
+		b.NamesFile = value
+	case bannerAddKey:
+		json.Unmarshal([]byte(value), &b.Add)
+	}
+}
+
+func (b Banner) lines() []string {
+	lines := []string{managedNotice, fmt.Sprintf("# %s: %s", bannerSourceKey, b.Source)}
+	if len(b.Names) > 0 {
+		names, _ := json.Marshal(b.Names)
+		lines = append(lines, fmt.Sprintf("# %s: %s", bannerNamesKey, names))
+	}
+	if b.NamesFile != "" {
+		lines = append(lines, fmt.Sprintf("# %s: %s", bannerNamesFileKey, b.NamesFile))
+	}
+	if len(b.Add) > 0 {
+		add, _ := json.Marshal(b.Add)
+		lines = append(lines, fmt.Sprintf("# %s: %s", bannerAddKey, add))
+	}
+	return lines
+}
+
+// WriteManagedFile writes the text before the managed section, the managed
+// section, with its banner and sections, and the text after it
+func WriteManagedFile(ignoreFile io.Writer, mf ManagedFile) error {
+	var b strings.Builder
+	b.WriteString(mf.Before)
+	b.WriteString(ManagedBeginMarker + "\n")
+	for _, line := range mf.Banner.lines() {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	if err := WriteIgnoreFile(&b, mf.Sections); err != nil {
+		return err
+	}
+	if len(mf.Sections) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(ManagedEndMarker + "\n")
+	b.WriteString(mf.After)
+	_, err := io.WriteString(ignoreFile, b.String())
+	return err
+}
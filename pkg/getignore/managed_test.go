@@ -0,0 +1,81 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("ManagedFile", func() {
+	contents := `# Hand-written
+*.log
+
+# BEGIN getignore
+# Managed by getignore; run ` + "`getignore update`" + ` rather than editing between these markers.
+# source: github/gitignore@main
+# names: ["Go","Global/Vim"]
+# names-file: names.txt
+# add: ["tmp/","!tmp/.keep"]
+
+######
+# Go #
+######
+*.o
+
+
+#######
+# Vim #
+#######
+.*.swp
+
+
+##########
+# Custom #
+##########
+tmp/
+!tmp/.keep
+
+# END getignore
+local/
+`
+
+	It("should parse the managed section", func() {
+		mf, ok := getignore.ParseManagedFile(contents)
+		Expect(ok).Should(BeTrue())
+		Expect(mf.Before).Should(Equal("# Hand-written\n*.log\n\n"))
+		Expect(mf.After).Should(Equal("local/\n"))
+		Expect(mf.BeginLine).Should(Equal(4))
+		Expect(mf.Banner).Should(Equal(getignore.Banner{
+			Source:    "github/gitignore@main",
+			Names:     []string{"Go", "Global/Vim"},
+			NamesFile: "names.txt",
+			Add:       []string{"tmp/", "!tmp/.keep"},
+		}))
+		Expect(mf.Sections).Should(HaveLen(3))
+		Expect(mf.Sections[1].DisplayName()).Should(Equal("Vim"))
+		Expect(mf.Sections[1].Contents).Should(Equal(".*.swp\n"))
+		Expect(mf.SectionLines).Should(Equal([]int{12, 18, 24}))
+	})
+
+	It("should report files without a managed section", func() {
+		_, ok := getignore.ParseManagedFile("*.log\n# BEGIN getignore\n")
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("should write the file it parsed", func() {
+		mf, _ := getignore.ParseManagedFile(contents)
+		outputFile := bytes.NewBufferString("")
+		Expect(getignore.WriteManagedFile(outputFile, mf)).Should(Succeed())
+		Expect(outputFile.String()).Should(Equal(contents))
+	})
+
+	It("should write a managed section without sections", func() {
+		outputFile := bytes.NewBufferString("")
+		mf := getignore.ManagedFile{Banner: getignore.Banner{Source: "github/gitignore@main"}}
+		Expect(getignore.WriteManagedFile(outputFile, mf)).Should(Succeed())
+		Expect(outputFile.String()).Should(HaveSuffix("# source: github/gitignore@main\n\n# END getignore\n"))
+	})
+})
@@ -10,6 +10,9 @@ import (
 type NamedContents struct {
 	Name     string
 	Contents string
+	// Source identifies where the contents came from: a source as given by
+	// SplitSource, where the empty source is the default one, or LocalSource
+	Source string
 }
 
 // DisplayName returns the decorated name, suitable for a section header in a
@@ -0,0 +1,98 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OutdatedFile is a locked gitignore patterns file that has changed in its
+// source since it was locked
+type OutdatedFile struct {
+	LockedFile
+	// LatestSHA is the SHA of the file in its source now, or empty if the
+	// file has been removed
+	LatestSHA string
+}
+
+// Removed reports whether the file has been removed from its source
+func (f OutdatedFile) Removed() bool {
+	return f.LatestSHA == ""
+}
+
+// FindOutdated compares the locked files with the entries currently
+// available from their source, returning those that have changed or been
+// removed
+func FindOutdated(lockedFiles []LockedFile, entries []FileEntry) []OutdatedFile {
+	latestSHAs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		latestSHAs[entry.Path] = entry.SHA
+	}
+	var outdated []OutdatedFile
+	for _, lf := range lockedFiles {
+		if latestSHA := latestSHAs[lf.Path]; latestSHA != lf.SHA {
+			outdated = append(outdated, OutdatedFile{LockedFile: lf, LatestSHA: latestSHA})
+		}
+	}
+	return outdated
+}
+
+// ManagedSectionFiles returns the files the sections of a managed file were
+// generated from, for a managed file without a lock file, given the current
+// contents of the files its banner describes, using the default source for
+// contents without one. Each section is matched with the first file not yet
+// matched with the same name, as by Lock.SectionFiles. The SHA recorded for a
+// file is that of its current contents if its section has them, or else of
+// the contents of its section, as given by SectionContents; files without a
+// section, or given inline, are left out.
+func ManagedSectionFiles(mf ManagedFile, current []NamedContents, defaultSource string) []LockedFile {
+	unmatched := make(map[string][]NamedContents, len(current))
+	for _, nc := range current {
+		if nc.Name == InlineContentsName {
+			continue
+		}
+		name := nc.DisplayName()
+		unmatched[name] = append(unmatched[name], nc)
+	}
+	var files []LockedFile
+	for _, section := range mf.Sections {
+		name := section.DisplayName()
+		candidates := unmatched[name]
+		if len(candidates) == 0 {
+			continue
+		}
+		nc := candidates[0]
+		unmatched[name] = candidates[1:]
+		source := nc.Source
+		if source == "" {
+			source = defaultSource
+		}
+		contents := nc.Contents
+		if strings.TrimSpace(section.Contents) != strings.TrimSpace(nc.Contents) {
+			contents = SectionContents(section)
+		}
+		files = append(files, LockedFile{Source: source, Path: nc.Name, SHA: BlobSHA(contents)})
+	}
+	return files
+}
+
+// SectionContents returns the contents of a section of a gitignore file as
+// those of a file, without the blank lines around them
+func SectionContents(section NamedContents) string {
+	return strings.TrimSpace(section.Contents) + "\n"
+}
+
+// WriteOutdated writes each outdated file, its source, and its locked and
+// latest abbreviated SHAs on a line
+func WriteOutdated(outdatedFile io.Writer, outdated []OutdatedFile) error {
+	writer := bufio.NewWriter(outdatedFile)
+	for _, f := range outdated {
+		latest := "removed"
+		if !f.Removed() {
+			latest = shortSHA(f.LatestSHA)
+		}
+		fmt.Fprintf(writer, "%s (%s): %s -> %s\n", f.Path, f.Source, shortSHA(f.SHA), latest)
+	}
+	return writer.Flush()
+}
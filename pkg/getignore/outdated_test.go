@@ -0,0 +1,91 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Outdated", func() {
+	lockedFiles := []getignore.LockedFile{
+		{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: "66fd13c903cac02eb9657cd53fb227823484401d"},
+		{Source: "github/gitignore@main", Path: "Node.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4"},
+		{Source: "github/gitignore@main", Path: "Global/Vim.gitignore", SHA: "dc9d020aee1ebc1a23c02d80a1c33c0cb35ebaeb"},
+	}
+	entries := []getignore.FileEntry{
+		{Path: "Go.gitignore", SHA: "5d947ca8879f8a9072fe485c566204e3c2929e80"},
+		{Path: "Node.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4"},
+	}
+
+	It("should find files that changed or were removed", func() {
+		Expect(getignore.FindOutdated(lockedFiles, entries)).Should(Equal([]getignore.OutdatedFile{
+			{LockedFile: lockedFiles[0], LatestSHA: "5d947ca8879f8a9072fe485c566204e3c2929e80"},
+			{LockedFile: lockedFiles[2]},
+		}))
+	})
+
+	It("should find nothing when all files are up to date", func() {
+		Expect(getignore.FindOutdated(lockedFiles[1:2], entries)).Should(BeEmpty())
+	})
+
+	Describe("ManagedSectionFiles", func() {
+		mf := getignore.ManagedFile{
+			Banner: getignore.Banner{Source: "github/gitignore@main", Names: []string{"Go", "Vim", "add:tmp/", "community/Vim"}},
+			Sections: []getignore.NamedContents{
+				{Name: "Go", Contents: "*.o\n\n"},
+				{Name: "Vim", Contents: "*.swp\n"},
+				{Name: "Custom", Contents: "tmp/\n"},
+				{Name: "Vim", Contents: "*.un~\n"},
+			},
+		}
+
+		It("should record the current SHAs of files with sections as they are now", func() {
+			current := []getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n\n"},
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "Custom", Contents: "tmp/\n"},
+				{Name: "community/Vim.gitignore", Contents: "*.un~\n", Source: "acme/templates"},
+			}
+			Expect(getignore.ManagedSectionFiles(mf, current, "github/gitignore@main")).Should(Equal([]getignore.LockedFile{
+				{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n\n")},
+				{Source: "github/gitignore@main", Path: "Global/Vim.gitignore", SHA: getignore.BlobSHA("*.swp\n")},
+				{Source: "acme/templates", Path: "community/Vim.gitignore", SHA: getignore.BlobSHA("*.un~\n")},
+			}))
+		})
+
+		It("should record the SHAs of the sections of files that have changed", func() {
+			current := []getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n*.so\n"},
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "community/Vim.gitignore", Contents: "*.un~\nSession.vim\n"},
+			}
+			Expect(getignore.ManagedSectionFiles(mf, current, "github/gitignore@main")).Should(Equal([]getignore.LockedFile{
+				{Source: "github/gitignore@main", Path: "Go.gitignore", SHA: getignore.BlobSHA("*.o\n")},
+				{Source: "github/gitignore@main", Path: "Global/Vim.gitignore", SHA: getignore.BlobSHA("*.swp\n")},
+				{Source: "github/gitignore@main", Path: "community/Vim.gitignore", SHA: getignore.BlobSHA("*.un~\n")},
+			}))
+		})
+
+		It("should leave out files without sections", func() {
+			current := []getignore.NamedContents{{Name: "Node.gitignore", Contents: "node_modules/\n"}}
+			Expect(getignore.ManagedSectionFiles(mf, current, "github/gitignore@main")).Should(BeEmpty())
+		})
+	})
+
+	Describe("SectionContents", func() {
+		It("should trim the blank lines around the contents of a section", func() {
+			Expect(getignore.SectionContents(getignore.NamedContents{Name: "Go", Contents: "\n*.o\n\n\n"})).Should(Equal("*.o\n"))
+		})
+	})
+
+	It("should write the outdated files", func() {
+		outputFile := bytes.NewBufferString("")
+		Expect(getignore.WriteOutdated(outputFile, getignore.FindOutdated(lockedFiles, entries))).Should(Succeed())
+		Expect(outputFile.String()).Should(Equal(`Go.gitignore (github/gitignore@main): 66fd13c -> 5d947ca
+Global/Vim.gitignore (github/gitignore@main): dc9d020 -> removed
+`))
+	})
+})
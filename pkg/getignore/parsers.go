@@ -2,19 +2,105 @@ package getignore
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
-// ParseNamesFile reads a file containing one name of a gitignore patterns file per line
+// StdinNamesFile is the path of the names file that reads from standard input
+const StdinNamesFile = "-"
+
+// includeDirective starts a line in a names file that includes another file
+const includeDirective = "include"
+
+// exclusionPrefix starts a line in a names file that excludes a name
+const exclusionPrefix = "-"
+
+// NamesList holds the names read from a names file
+type NamesList struct {
+	// Names are the names of gitignore patterns files to get, in order
+	Names []string
+	// Exclusions are names and patterns of files to leave out
+	Exclusions []string
+}
+
+// ParseNamesFile reads a file containing one name of a gitignore patterns file per line,
+// ignoring blank lines and comments, which start with '#'
 func ParseNamesFile(namesFile io.Reader) []string {
 	var a []string
 	scanner := bufio.NewScanner(namesFile)
 	for scanner.Scan() {
-		name := strings.TrimSpace(scanner.Text())
+		name := stripComment(scanner.Text())
 		if len(name) > 0 {
 			a = append(a, name)
 		}
 	}
 	return a
 }
+
+// stripComment removes a comment, either the whole line or following
+// whitespace, along with surrounding whitespace
+func stripComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	for i := 1; i < len(line); i++ {
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return line
+}
+
+// ReadNamesFile reads the names file at the path, or standard input if the
+// path is StdinNamesFile. Besides names, each line may be an exclusion,
+// starting with '-', or an include directive, e.g., "include
+// other-names.txt", which reads the names file at a path relative to the
+// directory of the including file.
+func ReadNamesFile(path string) (NamesList, error) {
+	var list NamesList
+	err := readNamesFile(path, &list, make(map[string]bool))
+	return list, err
+}
+
+func readNamesFile(path string, list *NamesList, including map[string]bool) error {
+	var lines []string
+	if path == StdinNamesFile {
+		lines = ParseNamesFile(os.Stdin)
+	} else {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("unable to read names file %s: %w", path, err)
+		}
+		if including[absPath] {
+			return fmt.Errorf("unable to read names file %s: it includes itself", path)
+		}
+		including[absPath] = true
+		defer delete(including, absPath)
+		namesFile, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("unable to read names file: %w", err)
+		}
+		defer namesFile.Close()
+		lines = ParseNamesFile(namesFile)
+	}
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 1 && fields[0] == includeDirective {
+			includedPath := strings.TrimSpace(strings.TrimPrefix(line, includeDirective))
+			if path != StdinNamesFile && !filepath.IsAbs(includedPath) {
+				includedPath = filepath.Join(filepath.Dir(path), includedPath)
+			}
+			if err := readNamesFile(includedPath, list, including); err != nil {
+				return err
+			}
+		} else if strings.HasPrefix(line, exclusionPrefix) {
+			list.Exclusions = append(list.Exclusions, strings.TrimSpace(strings.TrimPrefix(line, exclusionPrefix)))
+		} else {
+			list.Names = append(list.Names, line)
+		}
+	}
+	return nil
+}
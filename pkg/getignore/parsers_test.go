@@ -1,6 +1,9 @@
 package getignore_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo"
@@ -27,4 +30,72 @@ var _ = Describe("ParseNamesFile", func() {
 	It("strips whitespace", func() {
 		assertReturnsExpectedNames("Global/Vim   \n  \n   Python\n")
 	})
+
+	It("ignores comments", func() {
+		assertReturnsExpectedNames("# Editors\nGlobal/Vim # for the terminal\n  # Languages\nPython\n")
+	})
+})
+
+var _ = Describe("ReadNamesFile", func() {
+	var dir string
+
+	writeNamesFile := func(name string, contents string) string {
+		p := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(p), 0o755)).Should(Succeed())
+		Expect(os.WriteFile(p, []byte(contents), 0o644)).Should(Succeed())
+		return p
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "getignore")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("reads names, exclusions, and source-qualified names", func() {
+		p := writeNamesFile("names.txt", "Go\n-Global/Vim\ngithub/gitignore@v1:Node\n")
+		namesList, err := getignore.ReadNamesFile(p)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(namesList).Should(Equal(getignore.NamesList{
+			Names:      []string{"Go", "github/gitignore@v1:Node"},
+			Exclusions: []string{"Global/Vim"},
+		}))
+	})
+
+	It("includes files relative to the including file", func() {
+		writeNamesFile("shared/editors.txt", "Global/*\n")
+		writeNamesFile("shared/common.txt", "include editors.txt\nGo\n")
+		p := writeNamesFile("names.txt", "include shared/common.txt\n-Global/Vim\nNode\n")
+		namesList, err := getignore.ReadNamesFile(p)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(namesList).Should(Equal(getignore.NamesList{
+			Names:      []string{"Global/*", "Go", "Node"},
+			Exclusions: []string{"Global/Vim"},
+		}))
+	})
+
+	It("includes the same file more than once", func() {
+		writeNamesFile("go.txt", "Go\n")
+		p := writeNamesFile("names.txt", "include go.txt\ninclude go.txt\n")
+		namesList, err := getignore.ReadNamesFile(p)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(namesList.Names).Should(Equal([]string{"Go", "Go"}))
+	})
+
+	It("fails when a file includes itself", func() {
+		writeNamesFile("a.txt", "include b.txt\n")
+		writeNamesFile("b.txt", "include a.txt\n")
+		_, err := getignore.ReadNamesFile(filepath.Join(dir, "a.txt"))
+		Expect(err).Should(MatchError(ContainSubstring("it includes itself")))
+	})
+
+	It("fails when the file does not exist", func() {
+		_, err := getignore.ReadNamesFile(filepath.Join(dir, "missing.txt"))
+		Expect(err).Should(MatchError(ContainSubstring("unable to read names file")))
+		Expect(os.IsNotExist(errors.Unwrap(err))).Should(BeTrue())
+	})
 })
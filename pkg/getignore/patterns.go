@@ -0,0 +1,130 @@
+package getignore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexpPrefix marks a pattern as a regular expression rather than a glob
+const RegexpPrefix = "re:"
+
+// Pattern matches paths of gitignore patterns files, either by a glob, e.g.,
+// "Global/*", or by a regular expression prefixed with RegexpPrefix, e.g.,
+// "re:^community/Java".
+//
+// In globs, "*" matches any sequence of characters other than "/", "**"
+// matches any sequence of characters including "/", "**/" matches zero or
+// more directories, "?" matches any single character other than "/", and
+// "[...]" matches a character class.
+type Pattern struct {
+	raw    string
+	regexp *regexp.Regexp
+}
+
+// IsPattern reports whether a name should be treated as a Pattern rather than
+// a name of a gitignore patterns file
+func IsPattern(name string) bool {
+	return strings.HasPrefix(name, RegexpPrefix) || strings.ContainsAny(name, "*?[")
+}
+
+// ParsePattern parses a glob or prefixed regular expression
+func ParsePattern(s string) (Pattern, error) {
+	expr := strings.TrimPrefix(s, RegexpPrefix)
+	if expr == s {
+		expr = globToRegexp(s)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: %w", s, err)
+	}
+	return Pattern{raw: s, regexp: re}, nil
+}
+
+// ParsePatterns parses each of the given globs or prefixed regular
+// expressions
+func ParsePatterns(strs []string) ([]Pattern, error) {
+	var patterns []Pattern
+	for _, s := range strs {
+		pattern, err := ParsePattern(s)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Match reports whether the path matches the pattern
+func (p Pattern) Match(path string) bool {
+	return p.regexp.MatchString(path)
+}
+
+func (p Pattern) String() string {
+	return p.raw
+}
+
+// MatchAny reports whether the path matches any of the patterns
+func MatchAny(patterns []Pattern, path string) bool {
+	for _, pattern := range patterns {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp translates a glob to an equivalent anchored regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			if i+2 < len(runes) && runes[i+1] == '*' && runes[i+2] == '/' {
+				b.WriteString("(.*/)?")
+				i += 2
+			} else if i+1 < len(runes) && runes[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := indexRune(runes, ']', i+1)
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i = end
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// indexRune returns the index of the first instance of r in runes at or after
+// start, or -1 if r is not present
+func indexRune(runes []rune, r rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
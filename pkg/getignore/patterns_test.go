@@ -0,0 +1,96 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Pattern", func() {
+	Describe("IsPattern", func() {
+		It("should not treat plain names as patterns", func() {
+			Expect(getignore.IsPattern("Go")).Should(BeFalse())
+			Expect(getignore.IsPattern("Global/Vim.gitignore")).Should(BeFalse())
+		})
+
+		It("should treat globs as patterns", func() {
+			Expect(getignore.IsPattern("Global/*")).Should(BeTrue())
+			Expect(getignore.IsPattern("G?")).Should(BeTrue())
+			Expect(getignore.IsPattern("[GJ]ava")).Should(BeTrue())
+		})
+
+		It("should treat prefixed regular expressions as patterns", func() {
+			Expect(getignore.IsPattern("re:^community/Java")).Should(BeTrue())
+		})
+	})
+
+	Describe("Match", func() {
+		assertMatches := func(pattern string, path string, expected bool) {
+			p, err := getignore.ParsePattern(pattern)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.Match(path)).Should(Equal(expected))
+		}
+
+		It("should match a star within a directory", func() {
+			assertMatches("Global/*", "Global/Vim.gitignore", true)
+			assertMatches("Global/*", "community/Vim.gitignore", false)
+			assertMatches("*", "Global/Vim.gitignore", false)
+		})
+
+		It("should match a double star across directories", func() {
+			assertMatches("community/**", "community/AWS/SAM.gitignore", true)
+			assertMatches("**Java**", "community/Java/JBoss.gitignore", true)
+			assertMatches("**/JBoss*", "community/Java/JBoss.gitignore", true)
+		})
+
+		It("should match a double star and slash to no directories", func() {
+			assertMatches("**/Vim*", "Vim.gitignore", true)
+			assertMatches("**/Vim*", "Global/Vim.gitignore", true)
+		})
+
+		It("should match a question mark to exactly one character", func() {
+			assertMatches("G?.gitignore", "Go.gitignore", true)
+			assertMatches("G?.gitignore", "Godot.gitignore", false)
+		})
+
+		It("should match character classes", func() {
+			assertMatches("[GJ]*", "Java.gitignore", true)
+			assertMatches("[!GJ]*", "Java.gitignore", false)
+		})
+
+		It("should match other characters literally", func() {
+			assertMatches(`\*`, "*", true)
+			assertMatches("Go.gitignore", "Goxgitignore", false)
+		})
+
+		It("should match unanchored regular expressions", func() {
+			assertMatches("re:^community/Java", "community/Java/JBoss.gitignore", true)
+			assertMatches("re:Java", "community/Java/JBoss.gitignore", true)
+			assertMatches("re:^Java", "community/Java/JBoss.gitignore", false)
+		})
+	})
+
+	It("should report invalid regular expressions", func() {
+		_, err := getignore.ParsePattern("re:(")
+		Expect(err).Should(MatchError(HavePrefix(`invalid pattern "re:("`)))
+	})
+
+	It("should keep the original pattern as its string", func() {
+		p, _ := getignore.ParsePattern("Global/*")
+		Expect(p.String()).Should(Equal("Global/*"))
+	})
+
+	Describe("ParsePatterns", func() {
+		It("should parse all the patterns", func() {
+			patterns, err := getignore.ParsePatterns([]string{"Global/*", "re:Java"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(patterns).Should(HaveLen(2))
+		})
+
+		It("should fail if any pattern is invalid", func() {
+			_, err := getignore.ParsePatterns([]string{"Global/*", "re:("})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package getignore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrefixSource formats and parses sources giving the location of a
+// repository after a prefix naming its kind, e.g.,
+// index+https://example.com/templates/index.json
+type PrefixSource struct {
+	// Prefix precedes the location in a source, e.g., index+
+	Prefix string
+	// Location describes the location for messages, e.g., the URL of an index
+	Location string
+}
+
+// Format returns the source for the location, for recording in banners and
+// lock files
+func (ps PrefixSource) Format(location string) string {
+	return ps.Prefix + location
+}
+
+// Is reports whether the source has the prefix, as given by Format
+func (ps PrefixSource) Is(source string) bool {
+	return strings.HasPrefix(source, ps.Prefix)
+}
+
+// Parse parses a source given by Format into the location
+func (ps PrefixSource) Parse(source string) (string, error) {
+	if !ps.Is(source) {
+		return "", fmt.Errorf("invalid source %q: expected %s followed by %s", source, ps.Prefix, ps.Location)
+	}
+	location := strings.TrimPrefix(source, ps.Prefix)
+	if location == "" {
+		return "", fmt.Errorf("invalid source %q: missing %s", source, ps.Location)
+	}
+	return location, nil
+}
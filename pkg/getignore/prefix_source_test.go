@@ -0,0 +1,37 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("PrefixSource", func() {
+	source := getignore.PrefixSource{Prefix: "bundle+", Location: "the path of a bundle"}
+
+	It("should format a location", func() {
+		Expect(source.Format("/opt/getignore/templates.tar.gz")).Should(Equal("bundle+/opt/getignore/templates.tar.gz"))
+	})
+
+	It("should parse a location", func() {
+		location, err := source.Parse("bundle+templates.tar.gz")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(location).Should(Equal("templates.tar.gz"))
+	})
+
+	It("should identify sources with the prefix", func() {
+		Expect(source.Is("bundle+templates.tar.gz")).Should(BeTrue())
+		Expect(source.Is("index+https://example.com/templates/index.json")).Should(BeFalse())
+	})
+
+	It("should fail for other sources", func() {
+		_, err := source.Parse("github/gitignore@main")
+		Expect(err).Should(MatchError(`invalid source "github/gitignore@main": expected bundle+ followed by the path of a bundle`))
+	})
+
+	It("should fail without a location", func() {
+		_, err := source.Parse("bundle+")
+		Expect(err).Should(MatchError(`invalid source "bundle+": missing the path of a bundle`))
+	})
+})
@@ -0,0 +1,277 @@
+package getignore
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Resolver resolves requested names to the paths of gitignore patterns files
+// available from a source
+type Resolver struct {
+	// Suffix identifies gitignore patterns files, and is appended to names
+	// given without it
+	Suffix string
+	// Aliases maps alternative names to names. Names match aliases exactly,
+	// or else ignoring case, with the first alias in sorted order winning
+	// among those differing only in case.
+	Aliases map[string]string
+	// Fuzzy resolves names not otherwise found to their closest unambiguous
+	// match
+	Fuzzy bool
+	// Excludes removes matching paths from those resolved
+	Excludes []Pattern
+	// ExcludeNames removes the paths the names resolve to from those
+	// resolved; names that don't resolve are ignored
+	ExcludeNames []string
+}
+
+// ResolverSettings are the settings with which a getter resolves names;
+// getters embed them, so are NameResolvers
+type ResolverSettings struct {
+	Suffix       string
+	Fuzzy        bool
+	Aliases      map[string]string
+	Excludes     []Pattern
+	ExcludeNames []string
+}
+
+// Resolver returns the Resolver with the settings
+func (s ResolverSettings) Resolver() Resolver {
+	return Resolver{
+		Suffix:       s.Suffix,
+		Aliases:      s.Aliases,
+		Fuzzy:        s.Fuzzy,
+		Excludes:     s.Excludes,
+		ExcludeNames: s.ExcludeNames,
+	}
+}
+
+// ResolverOption sets one of the ResolverSettings; getters take them with
+// their WithResolver options
+type ResolverOption func(*ResolverSettings)
+
+// Apply sets the settings given by the options
+func (s *ResolverSettings) Apply(opts ...ResolverOption) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// WithSuffix sets the suffix to filter ignore files for
+func WithSuffix(suffix string) ResolverOption {
+	return func(s *ResolverSettings) {
+		s.Suffix = suffix
+	}
+}
+
+// WithFuzzy sets whether names not present are resolved to their closest
+// unambiguous match
+func WithFuzzy(fuzzy bool) ResolverOption {
+	return func(s *ResolverSettings) {
+		s.Fuzzy = fuzzy
+	}
+}
+
+// WithAliases sets alternative names for gitignore patterns files, e.g.,
+// "jetbrains" for "Global/JetBrains"
+func WithAliases(aliases map[string]string) ResolverOption {
+	return func(s *ResolverSettings) {
+		s.Aliases = aliases
+	}
+}
+
+// WithExcludes sets patterns for files to leave out when getting files
+func WithExcludes(excludes []Pattern) ResolverOption {
+	return func(s *ResolverSettings) {
+		s.Excludes = excludes
+	}
+}
+
+// WithExcludeNames sets names of files to leave out when getting files
+func WithExcludeNames(names []string) ResolverOption {
+	return func(s *ResolverSettings) {
+		s.ExcludeNames = names
+	}
+}
+
+// Resolve resolves each name to one of the given paths. It returns the
+// resolved paths in the order of the names, with duplicates removed, along
+// with a FailedFile for each name that could not be resolved.
+//
+// A name is resolved by trying, in order: an alias, the exact path, the path
+// with the suffix appended, a case-insensitive match of the path, and a
+// case-insensitive match of the base name in any directory. A name for which
+// IsPattern is true is instead expanded to all the paths with the suffix
+// matching it.
+//
+// Paths matching Excludes, or to which ExcludeNames resolve, are left out.
+func (r Resolver) Resolve(names []string, paths []string) ([]string, FailedFiles) {
+	available := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		available[p] = true
+	}
+	candidates := r.candidates(paths)
+	aliases := newAliasIndex(r.Aliases)
+	var (
+		resolved    []string
+		failedFiles FailedFiles
+	)
+	seen := r.excludedPaths(available, candidates, aliases)
+	for _, name := range names {
+		var (
+			matches    []string
+			failedFile *FailedFile
+		)
+		if IsPattern(name) {
+			matches, failedFile = r.expandPattern(name, candidates)
+		} else {
+			var p string
+			p, failedFile = r.resolveName(name, available, candidates, aliases)
+			matches = []string{p}
+		}
+		if failedFile != nil {
+			failedFiles = append(failedFiles, *failedFile)
+			continue
+		}
+		for _, p := range matches {
+			if !seen[p] && !MatchAny(r.Excludes, p) {
+				seen[p] = true
+				resolved = append(resolved, p)
+			}
+		}
+	}
+	return resolved, failedFiles
+}
+
+// excludedPaths returns the set of paths to which ExcludeNames resolve
+func (r Resolver) excludedPaths(available map[string]bool, candidates []string, aliases aliasIndex) map[string]bool {
+	excluded := make(map[string]bool)
+	for _, name := range r.ExcludeNames {
+		if IsPattern(name) {
+			matches, _ := r.expandPattern(name, candidates)
+			for _, p := range matches {
+				excluded[p] = true
+			}
+		} else if p, failedFile := r.resolveName(name, available, candidates, aliases); failedFile == nil {
+			excluded[p] = true
+		}
+	}
+	return excluded
+}
+
+func (r Resolver) expandPattern(name string, candidates []string) ([]string, *FailedFile) {
+	pattern, err := ParsePattern(name)
+	if err != nil {
+		return nil, &FailedFile{
+			Name:    name,
+			Message: err.Error(),
+			Err:     err,
+		}
+	}
+	var matches []string
+	for _, candidate := range candidates {
+		if pattern.Match(candidate) {
+			matches = append(matches, candidate)
+		}
+	}
+	if matches == nil {
+		return nil, &FailedFile{
+			Name:    name,
+			Message: "no files match pattern",
+		}
+	}
+	return matches, nil
+}
+
+// resolveName resolves the name to a path, failing with the name as given,
+// rather than any alias target
+func (r Resolver) resolveName(name string, available map[string]bool, candidates []string, aliases aliasIndex) (string, *FailedFile) {
+	given := strings.TrimSpace(name)
+	name = aliases.expand(given)
+	if available[name] {
+		return name, nil
+	}
+	if r.Suffix != "" && !strings.HasSuffix(name, r.Suffix) && available[name+r.Suffix] {
+		return name + r.Suffix, nil
+	}
+	wanted := normalizeName(name, r.Suffix)
+	var sameBase []string
+	for _, candidate := range candidates {
+		normalized := normalizeName(candidate, r.Suffix)
+		if normalized == wanted {
+			return candidate, nil
+		}
+		if path.Base(normalized) == path.Base(wanted) {
+			sameBase = append(sameBase, candidate)
+		}
+	}
+	if len(sameBase) == 1 {
+		return sameBase[0], nil
+	}
+	if len(sameBase) > 1 {
+		return "", &FailedFile{
+			Name:        given,
+			Message:     "ambiguous name",
+			Suggestions: sameBase,
+		}
+	}
+	if r.Fuzzy {
+		if match, ok := FuzzyMatch(name, candidates, r.Suffix); ok {
+			return match, nil
+		}
+	}
+	return "", &FailedFile{
+		Name:        given,
+		Message:     "not present in file tree",
+		Suggestions: Suggest(name, candidates, r.Suffix),
+	}
+}
+
+// aliasIndex looks up aliases exactly, and then ignoring case
+type aliasIndex struct {
+	exact  map[string]string
+	folded map[string]string
+}
+
+// newAliasIndex indexes the aliases, taking the first in sorted order among
+// aliases that differ only in case
+func newAliasIndex(aliases map[string]string) aliasIndex {
+	keys := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		keys = append(keys, alias)
+	}
+	sort.Strings(keys)
+	folded := make(map[string]string, len(aliases))
+	for _, alias := range keys {
+		if _, ok := folded[strings.ToLower(alias)]; !ok {
+			folded[strings.ToLower(alias)] = aliases[alias]
+		}
+	}
+	return aliasIndex{exact: aliases, folded: folded}
+}
+
+// expand returns the name the alias stands for, or the name itself if it is
+// not an alias
+func (a aliasIndex) expand(name string) string {
+	if target, ok := a.exact[name]; ok {
+		return target
+	}
+	if target, ok := a.folded[strings.ToLower(name)]; ok {
+		return target
+	}
+	return name
+}
+
+// candidates returns the sorted paths considered for inexact matches, which
+// are those identified by the suffix
+func (r Resolver) candidates(paths []string) []string {
+	var candidates []string
+	for _, p := range paths {
+		if strings.HasSuffix(p, r.Suffix) {
+			candidates = append(candidates, p)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
@@ -0,0 +1,216 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Resolver", func() {
+	var (
+		resolver getignore.Resolver
+		paths    = []string{
+			"README.md",
+			"Go.gitignore",
+			"Node.gitignore",
+			"Qt5.x.gitignore",
+			"Global/JetBrains.gitignore",
+			"Global/Vim.gitignore",
+			"community/Vim.gitignore",
+			"community/Golang/Hugo.gitignore",
+		}
+	)
+
+	BeforeEach(func() {
+		resolver = getignore.Resolver{Suffix: ".gitignore"}
+	})
+
+	assertResolves := func(name string, expectedPath string) {
+		It("should resolve the name", func() {
+			resolved, failedFiles := resolver.Resolve([]string{name}, paths)
+			Expect(failedFiles).Should(BeEmpty())
+			Expect(resolved).Should(Equal([]string{expectedPath}))
+		})
+	}
+
+	Context("the name is the exact path", func() {
+		assertResolves("Go.gitignore", "Go.gitignore")
+	})
+
+	Context("the name is the exact path of a file without the suffix", func() {
+		assertResolves("README.md", "README.md")
+	})
+
+	Context("the name lacks the suffix", func() {
+		assertResolves("Go", "Go.gitignore")
+	})
+
+	Context("the name contains a dot", func() {
+		assertResolves("Qt5.x", "Qt5.x.gitignore")
+	})
+
+	Context("the name differs in case", func() {
+		assertResolves("global/jetbrains", "Global/JetBrains.gitignore")
+	})
+
+	Context("the name is a base name in a subdirectory", func() {
+		assertResolves("hugo", "community/Golang/Hugo.gitignore")
+	})
+
+	Context("the name is an alias", func() {
+		BeforeEach(func() {
+			resolver.Aliases = map[string]string{
+				"node":      "Node",
+				"jetbrains": "Global/JetBrains",
+			}
+		})
+
+		assertResolves("jetbrains", "Global/JetBrains.gitignore")
+		assertResolves("JetBrains", "Global/JetBrains.gitignore")
+
+		It("should fail with the alias as given", func() {
+			resolver.Aliases["ts"] = "TypeScript"
+			_, failedFiles := resolver.Resolve([]string{"ts"}, paths)
+			Expect(failedFiles).Should(HaveLen(1))
+			Expect(failedFiles[0].Name).Should(Equal("ts"))
+		})
+	})
+
+	Context("aliases differ only in case", func() {
+		BeforeEach(func() {
+			resolver.Aliases = map[string]string{
+				"Vim": "community/Vim",
+				"vim": "Global/Vim",
+				"VIM": "Go",
+			}
+		})
+
+		assertResolves("vim", "Global/Vim.gitignore")
+		assertResolves("Vim", "community/Vim.gitignore")
+		// "VIM" sorts first among the aliases folding to "vim"
+		assertResolves("viM", "Go.gitignore")
+	})
+
+	Context("the base name is present in several directories", func() {
+		It("should fail with the matching paths as suggestions", func() {
+			resolved, failedFiles := resolver.Resolve([]string{"vim"}, paths)
+			Expect(resolved).Should(BeEmpty())
+			Expect(failedFiles).Should(Equal(getignore.FailedFiles{
+				{
+					Name:        "vim",
+					Message:     "ambiguous name",
+					Suggestions: []string{"Global/Vim.gitignore", "community/Vim.gitignore"},
+				},
+			}))
+		})
+	})
+
+	Context("the name is misspelled", func() {
+		It("should fail with suggestions", func() {
+			_, failedFiles := resolver.Resolve([]string{"Hgo"}, paths)
+			Expect(failedFiles).Should(Equal(getignore.FailedFiles{
+				{
+					Name:        "Hgo",
+					Message:     "not present in file tree",
+					Suggestions: []string{"Go.gitignore", "community/Golang/Hugo.gitignore"},
+				},
+			}))
+		})
+
+		When("fuzzy matching is enabled", func() {
+			BeforeEach(func() {
+				resolver.Fuzzy = true
+			})
+
+			assertResolves("Nod", "Node.gitignore")
+		})
+	})
+
+	Context("several names resolve to the same path", func() {
+		It("should return the path once, in the order first requested", func() {
+			resolved, _ := resolver.Resolve([]string{"Node", "go", "Go", "node.gitignore"}, paths)
+			Expect(resolved).Should(Equal([]string{"Node.gitignore", "Go.gitignore"}))
+		})
+	})
+
+	Context("the name is a pattern", func() {
+		It("should resolve all matching files with the suffix", func() {
+			resolved, failedFiles := resolver.Resolve([]string{"Global/*"}, paths)
+			Expect(failedFiles).Should(BeEmpty())
+			Expect(resolved).Should(Equal([]string{"Global/JetBrains.gitignore", "Global/Vim.gitignore"}))
+		})
+
+		It("should resolve regular expressions", func() {
+			resolved, _ := resolver.Resolve([]string{"re:^community/"}, paths)
+			Expect(resolved).Should(Equal([]string{"community/Golang/Hugo.gitignore", "community/Vim.gitignore"}))
+		})
+
+		It("should fail when nothing matches", func() {
+			_, failedFiles := resolver.Resolve([]string{"Nonexistent/*"}, paths)
+			Expect(failedFiles).Should(MatchError(ContainSubstring("Nonexistent/*: no files match pattern")))
+		})
+
+		It("should fail when the pattern is invalid", func() {
+			_, failedFiles := resolver.Resolve([]string{"re:("}, paths)
+			Expect(failedFiles).Should(MatchError(ContainSubstring(`re:(: invalid pattern "re:("`)))
+		})
+	})
+
+	Context("exclusions are given", func() {
+		BeforeEach(func() {
+			resolver.Excludes, _ = getignore.ParsePatterns([]string{"**/Vim*", "re:Hugo"})
+		})
+
+		It("should leave out excluded files", func() {
+			resolved, failedFiles := resolver.Resolve([]string{"**", "Go"}, paths)
+			Expect(failedFiles).Should(BeEmpty())
+			Expect(resolved).Should(Equal([]string{
+				"Global/JetBrains.gitignore",
+				"Go.gitignore",
+				"Node.gitignore",
+				"Qt5.x.gitignore",
+			}))
+		})
+	})
+
+	Context("names to exclude are given", func() {
+		BeforeEach(func() {
+			resolver.ExcludeNames = []string{"Global/Vim", "community/**", "Nonexistent"}
+		})
+
+		It("should leave out the files the names resolve to", func() {
+			resolved, failedFiles := resolver.Resolve([]string{"Global/*", "community/Vim", "Go"}, paths)
+			Expect(failedFiles).Should(BeEmpty())
+			Expect(resolved).Should(Equal([]string{"Global/JetBrains.gitignore", "Go.gitignore"}))
+		})
+	})
+
+	It("should resolve the names it can and report those it can't", func() {
+		resolved, failedFiles := resolver.Resolve([]string{"Go", "Nonexistent", "Node"}, paths)
+		Expect(resolved).Should(Equal([]string{"Go.gitignore", "Node.gitignore"}))
+		Expect(failedFiles).Should(HaveLen(1))
+		Expect(failedFiles[0].Name).Should(Equal("Nonexistent"))
+	})
+})
+
+var _ = Describe("ResolverSettings", func() {
+	It("should apply the options", func() {
+		excludes, _ := getignore.ParsePatterns([]string{"community/**"})
+		var settings getignore.ResolverSettings
+		settings.Apply(
+			getignore.WithSuffix(".gitignore"),
+			getignore.WithFuzzy(true),
+			getignore.WithAliases(map[string]string{"golang": "Go"}),
+			getignore.WithExcludes(excludes),
+			getignore.WithExcludeNames([]string{"Vim"}),
+		)
+		Expect(settings.Resolver()).Should(Equal(getignore.Resolver{
+			Suffix:       ".gitignore",
+			Fuzzy:        true,
+			Aliases:      map[string]string{"golang": "Go"},
+			Excludes:     excludes,
+			ExcludeNames: []string{"Vim"},
+		}))
+	})
+})
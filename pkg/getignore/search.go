@@ -0,0 +1,61 @@
+package getignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// SearchResult represents a line of a gitignore patterns file matching a
+// search
+type SearchResult struct {
+	Name       string
+	LineNumber int
+	Line       string
+}
+
+// LineMatcher reports whether a line matches a search
+type LineMatcher func(line string) bool
+
+// NewLineMatcher returns a LineMatcher for lines containing the pattern,
+// which is either literal text or, if isRegexp is true, a regular expression
+func NewLineMatcher(pattern string, isRegexp bool, ignoreCase bool) (LineMatcher, error) {
+	if !isRegexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	return re.MatchString, nil
+}
+
+// Search returns the lines of the contents that match, in the order of the
+// contents and their lines
+func Search(allContents []NamedContents, match LineMatcher) []SearchResult {
+	var results []SearchResult
+	for _, nc := range allContents {
+		for i, line := range strings.Split(nc.Contents, "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if match(line) {
+				results = append(results, SearchResult{Name: nc.Name, LineNumber: i + 1, Line: line})
+			}
+		}
+	}
+	return results
+}
+
+// WriteSearchResults writes each result as the name, line number, and line,
+// separated by colons
+func WriteSearchResults(resultsFile io.Writer, results []SearchResult) error {
+	writer := bufio.NewWriter(resultsFile)
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s:%d:%s\n", result.Name, result.LineNumber, result.Line)
+	}
+	return writer.Flush()
+}
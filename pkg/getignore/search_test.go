@@ -0,0 +1,61 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Search", func() {
+	contents := []getignore.NamedContents{
+		{Name: "Terraform.gitignore", Contents: "# Local .terraform directories\n**/.terraform/*\n\n*.tfstate\n*.tfstate.*\n"},
+		{Name: "Go.gitignore", Contents: "*.exe\r\n*.test\r\n"},
+	}
+
+	assertFindsLines := func(pattern string, isRegexp bool, ignoreCase bool, expected []getignore.SearchResult) {
+		match, err := getignore.NewLineMatcher(pattern, isRegexp, ignoreCase)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getignore.Search(contents, match)).Should(Equal(expected))
+	}
+
+	It("should find lines containing literal text", func() {
+		assertFindsLines("*.tfstate", false, false, []getignore.SearchResult{
+			{Name: "Terraform.gitignore", LineNumber: 4, Line: "*.tfstate"},
+			{Name: "Terraform.gitignore", LineNumber: 5, Line: "*.tfstate.*"},
+		})
+	})
+
+	It("should find lines matching a regular expression", func() {
+		assertFindsLines(`^\*\.t`, true, false, []getignore.SearchResult{
+			{Name: "Terraform.gitignore", LineNumber: 4, Line: "*.tfstate"},
+			{Name: "Terraform.gitignore", LineNumber: 5, Line: "*.tfstate.*"},
+			{Name: "Go.gitignore", LineNumber: 2, Line: "*.test"},
+		})
+	})
+
+	It("should respect case unless told to ignore it", func() {
+		assertFindsLines("LOCAL", false, false, nil)
+		assertFindsLines("LOCAL", false, true, []getignore.SearchResult{
+			{Name: "Terraform.gitignore", LineNumber: 1, Line: "# Local .terraform directories"},
+		})
+	})
+
+	It("should report invalid regular expressions", func() {
+		_, err := getignore.NewLineMatcher("(", true, false)
+		Expect(err).Should(MatchError(HavePrefix("invalid search pattern:")))
+	})
+
+	Describe("WriteSearchResults", func() {
+		It("should write the name, line number and line of each result", func() {
+			outputFile := bytes.NewBufferString("")
+			getignore.WriteSearchResults(outputFile, []getignore.SearchResult{
+				{Name: "Terraform.gitignore", LineNumber: 2, Line: "**/.terraform/*"},
+				{Name: "Terraform.gitignore", LineNumber: 4, Line: "*.tfstate"},
+			})
+			Expect(outputFile.String()).Should(Equal("Terraform.gitignore:2:**/.terraform/*\nTerraform.gitignore:4:*.tfstate\n"))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package getignore
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// SectionNames returns the names of the sections in contents written by
+// WriteIgnoreFile, in order, as given by the decorated section headers
+func SectionNames(contents string) []string {
+	_, sections := ParseSections(contents)
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = section.DisplayName()
+	}
+	return names
+}
+
+// sectionExtension is appended to section names to name parsed sections, so
+// that DisplayName returns the section name even when it contains a dot
+const sectionExtension = ".gitignore"
+
+// ParseSections splits contents written by WriteIgnoreFile or
+// AppendIgnoreFile into any text preceding the first section, and the
+// sections, each named so that its DisplayName is the section name
+func ParseSections(contents string) (string, []NamedContents) {
+	preamble, sections, _ := parseSectionLines(strings.Split(contents, "\n"))
+	return preamble, sections
+}
+
+// parseSectionLines parses sections from lines as ParseSections does, also
+// returning the index of the line on which each section's name appears
+func parseSectionLines(lines []string) (string, []NamedContents, []int) {
+	var (
+		preamble    []string
+		sections    []NamedContents
+		headerLines []int
+		body        []string
+	)
+	endSection := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Contents = strings.TrimSpace(strings.Join(body, "\n")) + "\n"
+		} else {
+			preamble = body
+		}
+		body = nil
+	}
+	for i := 0; i < len(lines); i++ {
+		if i+2 < len(lines) {
+			if name, ok := parseSectionHeader(lines[i : i+3]); ok {
+				endSection()
+				sections = append(sections, NamedContents{Name: name + sectionExtension})
+				headerLines = append(headerLines, i+1)
+				i += 2
+				continue
+			}
+		}
+		body = append(body, lines[i])
+	}
+	endSection()
+	return strings.TrimRight(strings.Join(preamble, "\n"), "\n"), sections, headerLines
+}
+
+// parseSectionHeader parses the three lines of a header produced by
+// decorateName
+func parseSectionHeader(lines []string) (string, bool) {
+	hashLine := lines[0]
+	nameLine := lines[1]
+	if len(hashLine) < 5 || strings.Trim(hashLine, "#") != "" || lines[2] != hashLine {
+		return "", false
+	}
+	if len(nameLine) != len(hashLine) || !strings.HasPrefix(nameLine, "# ") || !strings.HasSuffix(nameLine, " #") {
+		return "", false
+	}
+	return nameLine[2 : len(nameLine)-2], true
+}
+
+// AppendIgnoreFile writes the existing contents of a gitignore file followed
+// by sections for the contents, separated as WriteIgnoreFile separates them
+func AppendIgnoreFile(ignoreFile io.Writer, existing string, allContents []NamedContents) error {
+	writer := bufio.NewWriter(ignoreFile)
+	existing = strings.TrimRight(existing, "\n")
+	if existing != "" {
+		writer.WriteString(existing + "\n")
+		if len(allContents) > 0 {
+			writer.WriteString("\n\n")
+		}
+	}
+	if err := WriteIgnoreFile(writer, allContents); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// FindSectionPaths returns the path of the file each section came from,
+// among the entries: the one file whose name, as in the section header, is
+// exactly that of the section. Sections of inline patterns, and those
+// matching no file, such as those from local files, get the empty path. As
+// headers record only the base names of files, it fails for sections
+// matching several files, which need a managed section to record their full
+// names.
+func FindSectionPaths(sections []NamedContents, entries []FileEntry) ([]string, error) {
+	namedPaths := make(map[string][]string)
+	for _, entry := range entries {
+		nc := NamedContents{Name: entry.Path}
+		namedPaths[nc.DisplayName()] = append(namedPaths[nc.DisplayName()], entry.Path)
+	}
+	sectionPaths := make([]string, len(sections))
+	var failedFiles FailedFiles
+	for i, section := range sections {
+		name := section.DisplayName()
+		if name == InlineContentsName {
+			continue
+		}
+		if paths := namedPaths[name]; len(paths) == 1 {
+			sectionPaths[i] = paths[0]
+		} else if len(paths) > 1 {
+			failedFiles = append(failedFiles, FailedFile{
+				Name:        name,
+				Message:     "ambiguous name; use get --managed to record the full names of files",
+				Suggestions: paths,
+			})
+		}
+	}
+	if failedFiles != nil {
+		return nil, failedFiles
+	}
+	return sectionPaths, nil
+}
@@ -0,0 +1,120 @@
+package getignore_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Sections", func() {
+	existing := `#######
+# Vim #
+#######
+.*.swp
+
+
+######
+# Go #
+######
+*.o
+# Not a section #
+`
+
+	Describe("SectionNames", func() {
+		It("should return the names of decorated sections", func() {
+			Expect(getignore.SectionNames(existing)).Should(Equal([]string{"Vim", "Go"}))
+		})
+
+		It("should return nothing for files without sections", func() {
+			Expect(getignore.SectionNames("*.o\n#####\n")).Should(BeEmpty())
+		})
+	})
+
+	Describe("ParseSections", func() {
+		It("should split the contents into sections", func() {
+			preamble, sections := getignore.ParseSections(existing)
+			Expect(preamble).Should(BeEmpty())
+			Expect(sections).Should(Equal([]getignore.NamedContents{
+				{Name: "Vim.gitignore", Contents: ".*.swp\n"},
+				{Name: "Go.gitignore", Contents: "*.o\n# Not a section #\n"},
+			}))
+		})
+
+		It("should keep the text before the first section", func() {
+			preamble, sections := getignore.ParseSections("# Managed by hand\n*.log\n\n" + existing)
+			Expect(preamble).Should(Equal("# Managed by hand\n*.log"))
+			Expect(sections).Should(HaveLen(2))
+		})
+
+		It("should name sections so their display names are the section names", func() {
+			_, sections := getignore.ParseSections("#########\n# Qt5.x #\n#########\n*.pro.user\n")
+			Expect(sections[0].DisplayName()).Should(Equal("Qt5.x"))
+		})
+
+		It("should round trip with AppendIgnoreFile", func() {
+			preamble, sections := getignore.ParseSections(existing)
+			outputFile := bytes.NewBufferString("")
+			Expect(getignore.AppendIgnoreFile(outputFile, preamble, sections)).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal(existing))
+		})
+	})
+
+	Describe("AppendIgnoreFile", func() {
+		var outputFile *bytes.Buffer
+
+		BeforeEach(func() {
+			outputFile = bytes.NewBufferString("")
+		})
+
+		It("should add sections after the existing contents", func() {
+			ncs := []getignore.NamedContents{{Name: "Node.gitignore", Contents: "node_modules/\n"}}
+			Expect(getignore.AppendIgnoreFile(outputFile, existing+"\n\n", ncs)).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal(existing + `
+
+########
+# Node #
+########
+node_modules/
+`))
+		})
+
+		It("should write only the sections when there are no existing contents", func() {
+			ncs := []getignore.NamedContents{{Name: "Node.gitignore", Contents: "node_modules/\n"}}
+			Expect(getignore.AppendIgnoreFile(outputFile, "", ncs)).Should(Succeed())
+			Expect(outputFile.String()).Should(Equal("########\n# Node #\n########\nnode_modules/\n"))
+		})
+	})
+
+	Describe("FindSectionPaths", func() {
+		entries := []getignore.FileEntry{
+			{Path: "Go.gitignore"},
+			{Path: "Golang.gitignore"},
+			{Path: "Global/Vim.gitignore"},
+			{Path: "Global/JetBrains.gitignore"},
+			{Path: "community/JetBrains.gitignore"},
+		}
+
+		It("should find the file whose name is exactly that of each section", func() {
+			sections := []getignore.NamedContents{{Name: "Vim"}, {Name: "Go"}}
+			Expect(getignore.FindSectionPaths(sections, entries)).Should(Equal([]string{"Global/Vim.gitignore", "Go.gitignore"}))
+		})
+
+		It("should leave out sections of inline patterns and those matching no file", func() {
+			sections := []getignore.NamedContents{{Name: "Go"}, {Name: "Custom"}, {Name: "extra"}, {Name: "go"}}
+			Expect(getignore.FindSectionPaths(sections, entries)).Should(Equal([]string{"Go.gitignore", "", "", ""}))
+		})
+
+		It("should fail for sections matching several files", func() {
+			sections := []getignore.NamedContents{{Name: "Go"}, {Name: "JetBrains"}}
+			_, err := getignore.FindSectionPaths(sections, entries)
+			Expect(err).Should(MatchError(getignore.FailedFiles{{
+				Name:        "JetBrains",
+				Message:     "ambiguous name; use get --managed to record the full names of files",
+				Suggestions: []string{"Global/JetBrains.gitignore", "community/JetBrains.gitignore"},
+			}}))
+		})
+	})
+})
@@ -0,0 +1,161 @@
+package getignore
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sourceNameRegexp matches a name qualified by the repository it comes from,
+// e.g., github/gitignore@v1:Go or gitlab://group/project#main:Java, or by the
+// kind of host of the default repository, e.g., github:Go
+var sourceNameRegexp = regexp.MustCompile(`^([\w.-]+/[\w.-]+(?:@[^:\s]+)?|[a-z][a-z0-9-]*://[^#:\s]+(?:#[^:\s]+)?|[a-z][a-z0-9-]*):(.+)$`)
+
+// prefixedSourceRegexp matches a name qualified by a source given by a
+// PrefixSource, e.g., git+https://example.com/templates.git#main:Go, whose
+// location may itself hold colons
+var prefixedSourceRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*\+[^:\s][^\s]*:[^\s]`)
+
+// kindRegexp matches a kind of host given alone as a source, e.g., gitlab
+var kindRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// kindSeparator separates the kind of host from the location of a repository
+// in a source, e.g., gitlab://group/project#main
+const kindSeparator = "://"
+
+// Source lists and gets gitignore patterns files from a repository
+type Source interface {
+	Getter
+	// ListEntries lists the gitignore patterns files in the repository
+	ListEntries(ctx context.Context) ([]FileEntry, error)
+}
+
+// BlobGetter gets files by their git blob SHAs. Sources that implement it
+// list the SHAs of their entries, so files can be compared with lock files.
+type BlobGetter interface {
+	GetBlob(ctx context.Context, sha string) (string, error)
+}
+
+// RepositorySource identifies a repository of gitignore patterns files, and
+// optionally the branch, tag, or commit to get files at
+type RepositorySource struct {
+	// Kind is the kind of host of the repository, e.g., gitlab, or empty for
+	// the default one
+	Kind string
+	// Owner is the owner, organization, or group of the repository, which
+	// may be empty when Repository is a project ID
+	Owner      string
+	Repository string
+	Ref        string
+}
+
+// Path returns the path of the repository on its host
+func (rs RepositorySource) Path() string {
+	if rs.Owner == "" {
+		return rs.Repository
+	}
+	return rs.Owner + "/" + rs.Repository
+}
+
+// String returns the source in the form ParseRepositorySource parses
+func (rs RepositorySource) String() string {
+	if rs.Kind != "" && rs.Repository == "" {
+		return rs.Kind
+	}
+	if rs.Kind != "" {
+		source := rs.Kind + kindSeparator + rs.Path()
+		if rs.Ref != "" {
+			source += "#" + rs.Ref
+		}
+		return source
+	}
+	source := rs.Path()
+	if rs.Ref != "" {
+		source += "@" + rs.Ref
+	}
+	return source
+}
+
+// SplitSource splits a name qualified by its source, e.g.,
+// github/gitignore@v1:Go, gitlab://group/project#main:Java, github:Go, or
+// index+https://example.com/index.json:Go, into the source and the name. The
+// source is empty if the name is not qualified.
+func SplitSource(name string) (string, string) {
+	if IsLocalName(name) || IsInlineName(name) || IsPattern(name) && strings.HasPrefix(name, RegexpPrefix) {
+		return "", name
+	}
+	if prefixedSourceRegexp.MatchString(name) {
+		return splitPrefixedSource(name)
+	}
+	match := sourceNameRegexp.FindStringSubmatch(name)
+	if match == nil {
+		return "", name
+	}
+	return match[1], match[2]
+}
+
+// splitPrefixedSource splits a name qualified by a source given by a
+// PrefixSource at the last colon, as locations such as URLs may hold colons
+// but names do not, or else before a regular expression, which may
+func splitPrefixedSource(name string) (string, string) {
+	i := strings.Index(name, ":"+RegexpPrefix)
+	if i < 0 {
+		i = strings.LastIndex(name, ":")
+	}
+	return name[:i], name[i+1:]
+}
+
+// ParseRepositorySource parses a source of the form owner/repository, with
+// an optional ref, e.g., github/gitignore@v1, or of the form
+// kind://path#ref, e.g., gitlab://group/subgroup/project#main, where the
+// path may have any number of parts, or be a project ID, and the ref is
+// optional. A kind alone, e.g., gitlab, is parsed as the default repository
+// of that kind of host, leaving the repository empty.
+func ParseRepositorySource(source string) (RepositorySource, error) {
+	if i := strings.Index(source, kindSeparator); i >= 0 {
+		return parseKindSource(source, source[:i], source[i+len(kindSeparator):])
+	}
+	if kindRegexp.MatchString(source) {
+		return RepositorySource{Kind: source}, nil
+	}
+	var rs RepositorySource
+	repository := source
+	if i := strings.Index(source, "@"); i >= 0 {
+		repository, rs.Ref = source[:i], source[i+1:]
+		if rs.Ref == "" {
+			return RepositorySource{}, fmt.Errorf("invalid source %q: missing ref after @", source)
+		}
+	}
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return RepositorySource{}, fmt.Errorf("invalid source %q: expected owner/repository", source)
+	}
+	rs.Owner, rs.Repository = parts[0], parts[1]
+	return rs, nil
+}
+
+// parseKindSource parses the location of a source qualified by its kind
+func parseKindSource(source string, kind string, location string) (RepositorySource, error) {
+	if kind == "" {
+		return RepositorySource{}, fmt.Errorf("invalid source %q: missing kind before %s", source, kindSeparator)
+	}
+	rs := RepositorySource{Kind: kind}
+	if i := strings.Index(location, "#"); i >= 0 {
+		location, rs.Ref = location[:i], location[i+1:]
+		if rs.Ref == "" {
+			return RepositorySource{}, fmt.Errorf("invalid source %q: missing ref after #", source)
+		}
+	}
+	for _, part := range strings.Split(location, "/") {
+		if part == "" {
+			return RepositorySource{}, fmt.Errorf("invalid source %q: expected a repository path", source)
+		}
+	}
+	if i := strings.LastIndex(location, "/"); i >= 0 {
+		rs.Owner, rs.Repository = location[:i], location[i+1:]
+	} else {
+		rs.Repository = location
+	}
+	return rs, nil
+}
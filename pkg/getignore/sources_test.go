@@ -0,0 +1,170 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Sources", func() {
+	Describe("SplitSource", func() {
+		assertSplits := func(qualifiedName string, expectedSource string, expectedName string) {
+			source, name := getignore.SplitSource(qualifiedName)
+			Expect(source).Should(Equal(expectedSource))
+			Expect(name).Should(Equal(expectedName))
+		}
+
+		It("should split a name qualified by a repository and ref", func() {
+			assertSplits("github/gitignore@v1:Go", "github/gitignore@v1", "Go")
+		})
+
+		It("should split a name qualified by a repository", func() {
+			assertSplits("acme/templates:Global/Vim", "acme/templates", "Global/Vim")
+		})
+
+		It("should split a name qualified by a kind of host, repository, and ref", func() {
+			assertSplits("gitlab://group/project#main:Java", "gitlab://group/project#main", "Java")
+		})
+
+		It("should split a name qualified by a kind of host and repository", func() {
+			assertSplits("gitea://acme/templates:Global/*", "gitea://acme/templates", "Global/*")
+		})
+
+		It("should split a name qualified by a kind of host alone", func() {
+			assertSplits("github:Go", "github", "Go")
+			assertSplits("bitbucket-datacenter:Terraform", "bitbucket-datacenter", "Terraform")
+		})
+
+		It("should split a regular expression qualified by its source", func() {
+			assertSplits("acme/templates@v2:re:^Terra", "acme/templates@v2", "re:^Terra")
+		})
+
+		It("should split a name qualified by a git remote", func() {
+			assertSplits("git+file:///srv/git/templates#main:Go", "git+file:///srv/git/templates#main", "Go")
+			assertSplits("git+ssh://git@example.com:2222/templates.git:Global/Vim", "git+ssh://git@example.com:2222/templates.git", "Global/Vim")
+		})
+
+		It("should split a name qualified by an index", func() {
+			assertSplits("index+https://artifacts.example.com:8443/gitignore/index.json:Go", "index+https://artifacts.example.com:8443/gitignore/index.json", "Go")
+		})
+
+		It("should split a name qualified by a bundle", func() {
+			assertSplits("bundle+/opt/getignore/templates.tar.gz:Global/*", "bundle+/opt/getignore/templates.tar.gz", "Global/*")
+		})
+
+		It("should split a name qualified by a gitignore.io compatible API", func() {
+			assertSplits("gitignoreio+https://www.toptal.com/developers/gitignore:go", "gitignoreio+https://www.toptal.com/developers/gitignore", "go")
+		})
+
+		It("should split a regular expression qualified by a source with a prefix", func() {
+			assertSplits("index+https://example.com/index.json:re:^Terra(form)?:?", "index+https://example.com/index.json", "re:^Terra(form)?:?")
+		})
+
+		It("should leave unqualified names alone", func() {
+			assertSplits("Global/Vim", "", "Global/Vim")
+			assertSplits("c++", "", "c++")
+		})
+
+		It("should leave regular expressions alone", func() {
+			assertSplits("re:^community/Java", "", "re:^community/Java")
+		})
+
+		It("should leave local files alone", func() {
+			assertSplits("file:extra/local:names", "", "file:extra/local:names")
+		})
+
+		It("should leave patterns given inline alone", func() {
+			assertSplits("add:tmp/", "", "add:tmp/")
+			assertSplits("add:src/*:orig", "", "add:src/*:orig")
+		})
+	})
+
+	Describe("ParseRepositorySource", func() {
+		It("should parse the owner, repository, and ref", func() {
+			Expect(getignore.ParseRepositorySource("github/gitignore@v1")).Should(Equal(getignore.RepositorySource{
+				Owner:      "github",
+				Repository: "gitignore",
+				Ref:        "v1",
+			}))
+		})
+
+		It("should parse a source without a ref", func() {
+			Expect(getignore.ParseRepositorySource("acme/templates")).Should(Equal(getignore.RepositorySource{
+				Owner:      "acme",
+				Repository: "templates",
+			}))
+		})
+
+		It("should parse a kind of host alone", func() {
+			Expect(getignore.ParseRepositorySource("github")).Should(Equal(getignore.RepositorySource{Kind: "github"}))
+		})
+
+		It("should fail without a repository", func() {
+			_, err := getignore.ParseRepositorySource("acme/")
+			Expect(err).Should(MatchError(`invalid source "acme/": expected owner/repository`))
+		})
+
+		It("should fail for a name that is neither a kind of host nor a repository", func() {
+			_, err := getignore.ParseRepositorySource("Acme")
+			Expect(err).Should(MatchError(`invalid source "Acme": expected owner/repository`))
+		})
+
+		It("should fail with an empty ref", func() {
+			_, err := getignore.ParseRepositorySource("github/gitignore@")
+			Expect(err).Should(MatchError(`invalid source "github/gitignore@": missing ref after @`))
+		})
+
+		It("should parse a source qualified by its kind", func() {
+			Expect(getignore.ParseRepositorySource("gitlab://group/subgroup/templates#main")).Should(Equal(getignore.RepositorySource{
+				Kind:       "gitlab",
+				Owner:      "group/subgroup",
+				Repository: "templates",
+				Ref:        "main",
+			}))
+		})
+
+		It("should parse a project ID qualified by its kind", func() {
+			Expect(getignore.ParseRepositorySource("gitlab://1234")).Should(Equal(getignore.RepositorySource{
+				Kind:       "gitlab",
+				Repository: "1234",
+			}))
+		})
+
+		It("should fail with an empty ref after the kind", func() {
+			_, err := getignore.ParseRepositorySource("gitlab://group/templates#")
+			Expect(err).Should(MatchError(`invalid source "gitlab://group/templates#": missing ref after #`))
+		})
+
+		It("should fail with an empty path after the kind", func() {
+			_, err := getignore.ParseRepositorySource("gitlab://#main")
+			Expect(err).Should(MatchError(`invalid source "gitlab://#main": expected a repository path`))
+		})
+
+		It("should fail without a kind", func() {
+			_, err := getignore.ParseRepositorySource("://group/templates")
+			Expect(err).Should(MatchError(`invalid source "://group/templates": missing kind before ://`))
+		})
+	})
+
+	Describe("RepositorySource", func() {
+		It("should format a source without a kind", func() {
+			rs := getignore.RepositorySource{Owner: "github", Repository: "gitignore", Ref: "v1"}
+			Expect(rs.String()).Should(Equal("github/gitignore@v1"))
+		})
+
+		It("should format a source with a kind", func() {
+			rs := getignore.RepositorySource{Kind: "gitlab", Owner: "group/subgroup", Repository: "templates", Ref: "main"}
+			Expect(rs.String()).Should(Equal("gitlab://group/subgroup/templates#main"))
+		})
+
+		It("should format a project ID without a ref", func() {
+			rs := getignore.RepositorySource{Kind: "gitlab", Repository: "1234"}
+			Expect(rs.String()).Should(Equal("gitlab://1234"))
+		})
+
+		It("should format a kind of host alone", func() {
+			Expect(getignore.RepositorySource{Kind: "github"}.String()).Should(Equal("github"))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package getignore
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// MaxSuggestions is the maximum number of suggestions offered for a name
+// that could not be found
+var MaxSuggestions = 5
+
+const (
+	tierExactPath = iota
+	tierBaseName
+	tierPrefix
+	tierEditDistance
+)
+
+// suggestion is a candidate path ranked by how closely it matches a name
+type suggestion struct {
+	path     string
+	tier     int
+	distance int
+}
+
+// Suggest returns the candidate paths most similar to name, best match
+// first. Matching ignores case and the given suffix, and compares base names
+// so that names match across directories such as Global/ and community/.
+func Suggest(name string, candidates []string, suffix string) []string {
+	ranked := rankCandidates(name, candidates, suffix)
+	if len(ranked) > MaxSuggestions {
+		ranked = ranked[:MaxSuggestions]
+	}
+	var paths []string
+	for _, s := range ranked {
+		paths = append(paths, s.path)
+	}
+	return paths
+}
+
+// FuzzyMatch returns the candidate path that best matches name, provided it
+// matches unambiguously, i.e., no other candidate matches equally well.
+func FuzzyMatch(name string, candidates []string, suffix string) (string, bool) {
+	ranked := rankCandidates(name, candidates, suffix)
+	if len(ranked) == 0 {
+		return "", false
+	}
+	if len(ranked) > 1 && !ranked[0].betterThan(ranked[1]) {
+		return "", false
+	}
+	return ranked[0].path, true
+}
+
+func (s suggestion) betterThan(other suggestion) bool {
+	if s.tier != other.tier {
+		return s.tier < other.tier
+	}
+	return s.distance < other.distance
+}
+
+func rankCandidates(name string, candidates []string, suffix string) []suggestion {
+	wanted := normalizeName(name, suffix)
+	if wanted == "" {
+		return nil
+	}
+	wantedBase := path.Base(wanted)
+	var ranked []suggestion
+	for _, candidate := range candidates {
+		normalized := normalizeName(candidate, suffix)
+		base := path.Base(normalized)
+		var s suggestion
+		switch {
+		case normalized == wanted:
+			s = suggestion{path: candidate, tier: tierExactPath}
+		case base == wantedBase:
+			s = suggestion{path: candidate, tier: tierBaseName}
+		case strings.HasPrefix(base, wantedBase):
+			s = suggestion{path: candidate, tier: tierPrefix, distance: len(base) - len(wantedBase)}
+		default:
+			distance := min(editDistance(wanted, normalized), editDistance(wantedBase, base))
+			if distance > maxEditDistance(wantedBase) {
+				continue
+			}
+			s = suggestion{path: candidate, tier: tierEditDistance, distance: distance}
+		}
+		ranked = append(ranked, s)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].tier != ranked[j].tier || ranked[i].distance != ranked[j].distance {
+			return ranked[i].betterThan(ranked[j])
+		}
+		return ranked[i].path < ranked[j].path
+	})
+	return ranked
+}
+
+// normalizeName lower-cases a name and strips the suffix, if present
+func normalizeName(name string, suffix string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if suffix != "" {
+		name = strings.TrimSuffix(name, strings.ToLower(suffix))
+	}
+	return name
+}
+
+// maxEditDistance is the largest edit distance still considered a plausible
+// misspelling of a name of the given length
+func maxEditDistance(name string) int {
+	return max(1, len([]rune(name))/3)
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(min(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min(x int, y int) int {
+	if x <= y {
+		return x
+	}
+	return y
+}
+
+func max(x int, y int) int {
+	if x >= y {
+		return x
+	}
+	return y
+}
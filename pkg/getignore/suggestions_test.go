@@ -0,0 +1,82 @@
+package getignore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+var _ = Describe("Suggestions", func() {
+	candidates := []string{
+		"Go.gitignore",
+		"Godot.gitignore",
+		"Python.gitignore",
+		"Global/Vim.gitignore",
+		"Global/VisualStudioCode.gitignore",
+		"community/Golang/Hugo.gitignore",
+		"community/Python/JupyterNotebooks.gitignore",
+	}
+
+	Describe("Suggest", func() {
+		It("should match regardless of case", func() {
+			Expect(getignore.Suggest("go.gitignore", candidates, ".gitignore")).Should(Equal([]string{
+				"Go.gitignore",
+				"Godot.gitignore",
+			}))
+		})
+
+		It("should match base names across directories", func() {
+			Expect(getignore.Suggest("vim", candidates, ".gitignore")).Should(Equal([]string{
+				"Global/Vim.gitignore",
+			}))
+		})
+
+		It("should suggest close misspellings", func() {
+			Expect(getignore.Suggest("Pyhton", candidates, ".gitignore")).Should(Equal([]string{
+				"Python.gitignore",
+			}))
+		})
+
+		It("should rank exact matches before base name matches before prefixes", func() {
+			Expect(getignore.Suggest("Hugo", append(candidates, "Hugo.gitignore", "HugoStatic.gitignore"), ".gitignore")).Should(Equal([]string{
+				"Hugo.gitignore",
+				"community/Golang/Hugo.gitignore",
+				"HugoStatic.gitignore",
+			}))
+		})
+
+		It("should return nothing when nothing is similar", func() {
+			Expect(getignore.Suggest("Nonexistent", candidates, ".gitignore")).Should(BeEmpty())
+		})
+
+		It("should limit the number of suggestions", func() {
+			many := []string{"A1", "A2", "A3", "A4", "A5", "A6", "A7"}
+			Expect(getignore.Suggest("A", many, "")).Should(HaveLen(getignore.MaxSuggestions))
+		})
+	})
+
+	Describe("FuzzyMatch", func() {
+		It("should resolve a name differing in case", func() {
+			match, ok := getignore.FuzzyMatch("go", candidates, ".gitignore")
+			Expect(ok).Should(BeTrue())
+			Expect(match).Should(Equal("Go.gitignore"))
+		})
+
+		It("should resolve a name in a subdirectory", func() {
+			match, ok := getignore.FuzzyMatch("vim.gitignore", candidates, ".gitignore")
+			Expect(ok).Should(BeTrue())
+			Expect(match).Should(Equal("Global/Vim.gitignore"))
+		})
+
+		It("should not resolve an ambiguous name", func() {
+			_, ok := getignore.FuzzyMatch("Vim", append(candidates, "community/Vim.gitignore"), ".gitignore")
+			Expect(ok).Should(BeFalse())
+		})
+
+		It("should not resolve a name without any match", func() {
+			_, ok := getignore.FuzzyMatch("Nonexistent", candidates, ".gitignore")
+			Expect(ok).Should(BeFalse())
+		})
+	})
+})
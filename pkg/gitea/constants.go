@@ -0,0 +1,10 @@
+package gitea
+
+const (
+	Owner      = "github"
+	Repository = "gitignore"
+	Suffix     = ".gitignore"
+
+	apiPath      = "/api/v1"
+	treePageSize = 1000
+)
@@ -0,0 +1,321 @@
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = getignore.DefaultMaxRequests
+
+// Getter lists and gets files using the Gitea tree API, which Forgejo shares.
+type Getter struct {
+	client *http.Client
+	// BaseURL is the URL of the Gitea instance, without the API path
+	BaseURL    string
+	Owner      string
+	Repository string
+	// Ref is the branch, tag, or commit to list files at, or empty for the
+	// default branch of the repository
+	Ref         string
+	Token       string
+	MaxRequests int
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	client      *http.Client
+	baseURL     string
+	owner       string
+	repository  string
+	ref         string
+	token       string
+	maxRequests int
+	resolver    getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		client:      http.DefaultClient,
+		owner:       Owner,
+		repository:  Repository,
+		resolver:    getignore.ResolverSettings{Suffix: Suffix},
+		maxRequests: DefaultMaxRequests,
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.baseURL == "" {
+		return Getter{}, errors.New("a Gitea base URL is required")
+	}
+	if _, err := url.Parse(params.baseURL); err != nil {
+		return Getter{}, fmt.Errorf("invalid Gitea base URL: %w", err)
+	}
+	return Getter{
+		client:           params.client,
+		BaseURL:          strings.TrimSuffix(params.baseURL, "/"),
+		Owner:            params.owner,
+		Repository:       params.repository,
+		Ref:              params.ref,
+		Token:            params.token,
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithClient sets the HTTP client for the Getter
+func WithClient(client *http.Client) GetterOption {
+	return func(p *getterParams) {
+		p.client = client
+	}
+}
+
+// WithBaseURL sets the URL of the Gitea instance for the Getter
+func WithBaseURL(baseURL string) GetterOption {
+	return func(p *getterParams) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithOwner sets the owner or organization name for the Getter
+func WithOwner(owner string) GetterOption {
+	return func(p *getterParams) {
+		p.owner = owner
+	}
+}
+
+// WithRepository sets the repository name for the Getter
+func WithRepository(repository string) GetterOption {
+	return func(p *getterParams) {
+		p.repository = repository
+	}
+}
+
+// WithRef sets the branch, tag, or commit for the Getter
+func WithRef(ref string) GetterOption {
+	return func(p *getterParams) {
+		p.ref = ref
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// WithToken sets the access token to authenticate with
+func WithToken(token string) GetterOption {
+	return func(p *getterParams) {
+		p.token = token
+	}
+}
+
+// WithMaxRequests sets the number of maximum concurrent HTTP requests
+func WithMaxRequests(max int) GetterOption {
+	return func(p *getterParams) {
+		p.maxRequests = max
+	}
+}
+
+// treeEntry is an entry of the git tree of a repository
+type treeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+}
+
+// treePage is a page of the entries of a git tree
+type treePage struct {
+	Entries   []treeEntry `json:"tree"`
+	Truncated bool        `json:"truncated"`
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	_, tree, err := g.getTree(ctx)
+	if err != nil {
+		return nil, g.newListError(err)
+	}
+	var entries []getignore.FileEntry
+	for _, entry := range tree {
+		if entry.Type == "blob" && strings.HasSuffix(entry.Path, g.Suffix) {
+			entries = append(entries, getignore.FileEntry{Path: entry.Path, SHA: entry.SHA, Size: entry.Size})
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files downloaded from the given names
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	ref, tree, err := g.getTree(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	var paths []string
+	for _, entry := range tree {
+		if entry.Type == "blob" {
+			paths = append(paths, entry.Path)
+		}
+	}
+	names, unresolvedFiles := g.Resolver().Resolve(names, paths)
+	namedContents, failedFiles := getignore.DownloadFiles(ctx, names, g.MaxRequests, func(ctx context.Context, name string) (string, error) {
+		return g.getRaw(ctx, name, ref)
+	})
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+// GetBlob gets the contents of the blob with the SHA
+func (g Getter) GetBlob(ctx context.Context, sha string) (string, error) {
+	contents, err := g.getBlob(ctx, sha)
+	if err != nil {
+		return "", g.newGetError(getignore.FailedFile{
+			Name:    sha,
+			Message: "failed to download",
+			Err:     err,
+		})
+	}
+	return contents, nil
+}
+
+func (g Getter) newListError(err error) error {
+	return fmt.Errorf("error listing contents of %s/%s at %s: %w", g.Owner, g.Repository, g.refName(), err)
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s/%s at %s: %w", g.Owner, g.Repository, g.refName(), err)
+}
+
+// refName names the ref for messages
+func (g Getter) refName() string {
+	if g.Ref == "" {
+		return "the default branch"
+	}
+	return g.Ref
+}
+
+// getRef returns the ref to get files at, looking up the default branch of
+// the repository if no ref was given
+func (g Getter) getRef(ctx context.Context) (string, error) {
+	if g.Ref != "" {
+		return g.Ref, nil
+	}
+	body, err := g.get(ctx, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to get repository information: %w", err)
+	}
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return "", fmt.Errorf("unable to get repository information: %w", err)
+	}
+	if repository.DefaultBranch == "" {
+		return "", errors.New("no default branch information received")
+	}
+	return repository.DefaultBranch, nil
+}
+
+// getTree gets the ref and all the entries of the git tree at it, following
+// the pages of results
+func (g Getter) getTree(ctx context.Context) (string, []treeEntry, error) {
+	ref, err := g.getRef(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	var tree []treeEntry
+	query := url.Values{
+		"recursive": {"true"},
+		"per_page":  {strconv.Itoa(treePageSize)},
+	}
+	for page, truncated := 1, true; truncated; page++ {
+		query.Set("page", strconv.Itoa(page))
+		body, err := g.get(ctx, "git/trees/"+url.PathEscape(ref), query)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to get tree information: %w", err)
+		}
+		var tp treePage
+		if err := json.Unmarshal(body, &tp); err != nil {
+			return "", nil, fmt.Errorf("unable to get tree information: %w", err)
+		}
+		tree = append(tree, tp.Entries...)
+		truncated = tp.Truncated && len(tp.Entries) > 0
+	}
+	return ref, tree, nil
+}
+
+// getRaw gets the contents of the file at the path as of the ref
+func (g Getter) getRaw(ctx context.Context, filePath string, ref string) (string, error) {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	body, err := g.get(ctx, "raw/"+strings.Join(segments, "/"), url.Values{"ref": {ref}})
+	return string(body), err
+}
+
+// getBlob gets the contents of the blob with the SHA, which the blob API
+// encodes in base64
+func (g Getter) getBlob(ctx context.Context, sha string) (string, error) {
+	body, err := g.get(ctx, "git/blobs/"+url.PathEscape(sha), nil)
+	if err != nil {
+		return "", err
+	}
+	var blob struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := json.Unmarshal(body, &blob); err != nil {
+		return "", err
+	}
+	if blob.Encoding != "base64" {
+		return "", fmt.Errorf("unsupported blob encoding %q", blob.Encoding)
+	}
+	contents, err := base64.StdEncoding.DecodeString(blob.Content)
+	return string(contents), err
+}
+
+// get requests the resource of the repository with the query, returning the
+// body of the response
+func (g Getter) get(ctx context.Context, resource string, query url.Values) ([]byte, error) {
+	u := fmt.Sprintf("%s%s/repos/%s/%s", g.BaseURL, apiPath, url.PathEscape(g.Owner), url.PathEscape(g.Repository))
+	if resource != "" {
+		u += "/" + resource
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	body, _, err := getignore.HTTPGet(ctx, g.client, u, func(req *http.Request) {
+		if g.Token != "" {
+			req.Header.Set("Authorization", "token "+g.Token)
+		}
+	})
+	return body, err
+}
@@ -0,0 +1,262 @@
+package gitea_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitea"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const (
+	repositoryPath = "/api/v1/repos/acme/templates"
+	treePath       = "/api/v1/repos/acme/templates/git/trees/main"
+
+	treeResponse = `{
+  "sha": "5adf061bdde4dd26889be1e74028b2f54aabc346",
+  "tree": [
+	{
+	  "path": "Global",
+	  "mode": "040000",
+	  "type": "tree",
+	  "sha": "45f58ef9211cc06f3ef86585c7ecb1b3d52fd4f9"
+	},
+	{
+	  "path": "README.md",
+	  "mode": "100644",
+	  "type": "blob",
+	  "size": 103,
+	  "sha": "4009e0bc8b07582c19fa761810c9f3741ab76597"
+	},
+	{
+	  "path": "Global/Anjuta.gitignore",
+	  "mode": "100644",
+	  "type": "blob",
+	  "size": 78,
+	  "sha": "20dd42c53e6f0df8233fee457b664d443ee729f4"
+	}
+  ],
+  "truncated": true,
+  "page": 1,
+  "total_count": 4
+}`
+	nextTreeResponse = `{
+  "sha": "5adf061bdde4dd26889be1e74028b2f54aabc346",
+  "tree": [
+	{
+	  "path": "Go.gitignore",
+	  "mode": "100644",
+	  "type": "blob",
+	  "size": 269,
+	  "sha": "66fd13c903cac02eb9657cd53fb227823484401d"
+	}
+  ],
+  "truncated": false,
+  "page": 2,
+  "total_count": 4
+}`
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx               context.Context
+		server            *ghttp.Server
+		getter            gitea.Getter
+		expectedUserAgent = []string{fmt.Sprintf("getignore/%s", getignore.Version)}
+	)
+
+	// appendTreeHandlers responds to the requests for the two pages of the
+	// git tree at main
+	appendTreeHandlers := func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", treePath, "page=1&per_page=1000&recursive=true"),
+				ghttp.VerifyHeader(http.Header{
+					"User-Agent": expectedUserAgent,
+				}),
+				ghttp.RespondWith(http.StatusOK, treeResponse),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", treePath, "page=2&per_page=1000&recursive=true"),
+				ghttp.RespondWith(http.StatusOK, nextTreeResponse),
+			),
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+		getter, _ = gitea.NewGetter(
+			gitea.WithBaseURL(server.URL()),
+			gitea.WithOwner("acme"),
+			gitea.WithRepository("templates"),
+			gitea.WithRef("main"),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGetter", func() {
+		It("should require a base URL", func() {
+			_, err := gitea.NewGetter()
+			Expect(err).Should(MatchError("a Gitea base URL is required"))
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			appendTreeHandlers()
+		})
+
+		It("should list the files with the suffix from every page", func() {
+			files, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{"Global/Anjuta.gitignore", "Go.gitignore"}))
+		})
+
+		It("should return entries with the SHA and size of each file", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Global/Anjuta.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4", Size: 78},
+				{Path: "Go.gitignore", SHA: "66fd13c903cac02eb9657cd53fb227823484401d", Size: 269},
+			}))
+		})
+	})
+
+	Describe("List on the default branch with a token", func() {
+		BeforeEach(func() {
+			getter, _ = gitea.NewGetter(
+				gitea.WithBaseURL(server.URL()),
+				gitea.WithOwner("acme"),
+				gitea.WithRepository("templates"),
+				gitea.WithToken("secret"),
+			)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", repositoryPath),
+					ghttp.RespondWith(http.StatusOK, `{"name": "templates", "default_branch": "main"}`),
+				),
+			)
+			appendTreeHandlers()
+		})
+
+		It("should list the files on the default branch, sending the token", func() {
+			files, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{"Global/Anjuta.gitignore", "Go.gitignore"}))
+			for _, req := range server.ReceivedRequests() {
+				Expect(req.Header.Get("Authorization")).Should(Equal("token secret"))
+			}
+		})
+	})
+
+	Describe("List when the server errors", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"message": "GetTree"}`),
+			)
+		})
+
+		It("should return an error", func() {
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(HavePrefix("error listing contents of acme/templates at main: unable to get tree information: GET ")))
+			Expect(err).Should(MatchError(HaveSuffix(": 404 Not Found")))
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			goStatusCode int
+			goResponse   string
+		)
+
+		BeforeEach(func() {
+			goStatusCode = http.StatusOK
+			goResponse = "*.o\n*.a\n*.so\n"
+			appendTreeHandlers()
+			server.RouteToHandler("GET", "/api/v1/repos/acme/templates/raw/Go.gitignore", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/repos/acme/templates/raw/Go.gitignore", "ref=main"),
+				ghttp.VerifyHeader(http.Header{
+					"User-Agent": expectedUserAgent,
+				}),
+				ghttp.RespondWithPtr(&goStatusCode, &goResponse),
+			))
+			server.RouteToHandler("GET", "/api/v1/repos/acme/templates/raw/Global/Anjuta.gitignore", ghttp.RespondWith(http.StatusOK, "/.anjuta/\n"))
+		})
+
+		It("should return the contents of the files in the order of the names", func() {
+			contents, err := getter.Get(ctx, []string{"Go", "anjuta"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n*.a\n*.so\n"},
+				{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+			}))
+		})
+
+		It("should suggest files for names not present", func() {
+			_, err := getter.Get(ctx, []string{"Goo"})
+			Expect(err).Should(MatchError(ContainSubstring("Go.gitignore")))
+		})
+
+		When("a file request fails", func() {
+			BeforeEach(func() {
+				goStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return the other files and an error", func() {
+				contents, err := getter.Get(ctx, []string{"Go", "anjuta"})
+				Expect(contents).Should(Equal([]getignore.NamedContents{
+					{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+				}))
+				Expect(err).Should(MatchError("error getting files from acme/templates at main: failed to get the following files: Go.gitignore\nGo.gitignore: failed to download\n"))
+			})
+		})
+	})
+
+	Describe("GetBlob", func() {
+		var (
+			blobStatusCode int
+			blobResponse   string
+		)
+
+		BeforeEach(func() {
+			blobStatusCode = http.StatusOK
+			blobResponse = `{
+  "content": "Ki5vCiouYQoqLnNvCg==",
+  "encoding": "base64",
+  "sha": "66fd13c903cac02eb9657cd53fb227823484401d",
+  "size": 14
+}`
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/repos/acme/templates/git/blobs/66fd13c903cac02eb9657cd53fb227823484401d"),
+					ghttp.RespondWithPtr(&blobStatusCode, &blobResponse),
+				),
+			)
+		})
+
+		It("should return the decoded contents of the blob", func() {
+			contents, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal("*.o\n*.a\n*.so\n"))
+		})
+
+		When("the blob request fails", func() {
+			BeforeEach(func() {
+				blobStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+				Expect(err).Should(MatchError(ContainSubstring("66fd13c903cac02eb9657cd53fb227823484401d: failed to download")))
+			})
+		})
+	})
+})
@@ -6,5 +6,6 @@ const (
 	Branch     = "master"
 	Suffix     = ".gitignore"
 
-	userAgentTemplate = "getignore/%s"
+	// maxCommitsPerPage is the most commits the API lists in one page
+	maxCommitsPerPage = 100
 )
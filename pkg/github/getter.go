@@ -5,18 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path/filepath"
-	"runtime"
-	"sort"
+	"path"
 	"strings"
-	"sync"
 
 	"github.com/google/go-github/v39/github"
 	"github.com/gotgenes/getignore/pkg/getignore"
 )
 
 // DefaultMaxRequests is the default maximum number of concurrent requests
-var DefaultMaxRequests = runtime.NumCPU() - 1
+var DefaultMaxRequests = getignore.DefaultMaxRequests
 
 // Getter lists and gets files using the GitHub tree API.
 type Getter struct {
@@ -25,8 +22,8 @@ type Getter struct {
 	Owner       string
 	Repository  string
 	Branch      string
-	Suffix      string
 	MaxRequests int
+	getignore.ResolverSettings
 }
 
 // getterParams holds parameters for instantiating a Getter
@@ -36,8 +33,8 @@ type getterParams struct {
 	owner       string
 	repository  string
 	branch      string
-	suffix      string
 	maxRequests int
+	resolver    getignore.ResolverSettings
 }
 
 func NewGetter(options ...GetterOption) (Getter, error) {
@@ -45,7 +42,7 @@ func NewGetter(options ...GetterOption) (Getter, error) {
 		owner:       Owner,
 		repository:  Repository,
 		branch:      Branch,
-		suffix:      Suffix,
+		resolver:    getignore.ResolverSettings{Suffix: Suffix},
 		maxRequests: DefaultMaxRequests,
 	}
 	for _, option := range options {
@@ -63,16 +60,15 @@ func NewGetter(options ...GetterOption) (Getter, error) {
 	} else {
 		ghClient = github.NewClient(params.client)
 	}
-	userAgentString := fmt.Sprintf(userAgentTemplate, getignore.Version)
-	ghClient.UserAgent = userAgentString
+	ghClient.UserAgent = getignore.UserAgentString
 	return Getter{
-		client:      ghClient,
-		BaseURL:     params.baseURL,
-		Owner:       params.owner,
-		Repository:  params.repository,
-		Branch:      params.branch,
-		Suffix:      params.suffix,
-		MaxRequests: params.maxRequests,
+		client:           ghClient,
+		BaseURL:          params.baseURL,
+		Owner:            params.owner,
+		Repository:       params.repository,
+		Branch:           params.branch,
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
 	}, nil
 }
 
@@ -115,9 +111,7 @@ func WithBranch(branch string) GetterOption {
 
 // WithSuffix sets the suffix to filter ignore files for
 func WithSuffix(suffix string) GetterOption {
-	return func(p *getterParams) {
-		p.suffix = suffix
-	}
+	return WithResolver(getignore.WithSuffix(suffix))
 }
 
 // WithMaxRequests sets the number of maximum concurrent HTTP requests
@@ -127,18 +121,38 @@ func WithMaxRequests(max int) GetterOption {
 	}
 }
 
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
 // List returns an array of files filtered by the provided suffix.
 func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
 	tree, err := g.getTree(ctx)
 	if err != nil {
 		return nil, g.newListError(err)
 	}
-	entries := g.filterTreeEntries(tree.Entries)
-	var files []string
-	for _, entry := range entries {
-		files = append(files, entry.GetPath())
+	var entries []getignore.FileEntry
+	for _, entry := range g.filterTreeEntries(tree.Entries) {
+		entries = append(entries, getignore.FileEntry{
+			Path: entry.GetPath(),
+			SHA:  entry.GetSHA(),
+			Size: entry.GetSize(),
+		})
 	}
-	return files, nil
+	return entries, nil
 }
 
 // Get returns an array of contents of the files downloaded from the given names
@@ -148,58 +162,175 @@ func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedConte
 		return nil, g.newGetError(err)
 	}
 	pathsToSHAs := createPathsToSHAs(tree.Entries)
+	names, unresolvedFiles := g.Resolver().Resolve(names, entryPaths(tree.Entries))
+	namedContents, failedFiles := getignore.DownloadFiles(ctx, names, g.MaxRequests, func(ctx context.Context, name string) (string, error) {
+		blobContents, _, err := g.client.Git.GetBlobRaw(ctx, g.Owner, g.Repository, pathsToSHAs[name])
+		return string(blobContents), err
+	})
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
 
-	names = g.ensureSuffixes(names)
-	numNames := len(names)
-	namesChan, contentsChan, failedFilesChan := g.startDownloaders(ctx, numNames, pathsToSHAs)
+// Describe returns the details and contents of the file with the given name,
+// including the last commit to change it
+func (g Getter) Describe(ctx context.Context, name string) (getignore.FileDetails, error) {
+	tree, err := g.getTree(ctx)
+	if err != nil {
+		return getignore.FileDetails{}, g.newGetError(err)
+	}
+	entry, err := g.resolveEntry(tree.Entries, name)
+	if err != nil {
+		return getignore.FileDetails{}, g.newGetError(err)
+	}
+	filePath := entry.GetPath()
+	blobContents, _, err := g.client.Git.GetBlobRaw(ctx, g.Owner, g.Repository, entry.GetSHA())
+	if err != nil {
+		return getignore.FileDetails{}, g.newGetError(getignore.FailedFile{
+			Name:    filePath,
+			Message: "failed to download",
+			Err:     err,
+		})
+	}
+	commits, _, err := g.client.Repositories.ListCommits(ctx, g.Owner, g.Repository, &github.CommitsListOptions{
+		SHA:         g.Branch,
+		Path:        filePath,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return getignore.FileDetails{}, g.newGetError(getignore.FailedFile{
+			Name:    filePath,
+			Message: "unable to get commit information",
+			Err:     err,
+		})
+	}
+	details := getignore.FileDetails{
+		FileEntry: getignore.FileEntry{
+			Path: filePath,
+			SHA:  entry.GetSHA(),
+			Size: entry.GetSize(),
+		},
+		Contents: string(blobContents),
+		URL:      g.fileURL(filePath),
+	}
+	if len(commits) > 0 {
+		details.LastCommit = newCommit(commits[0])
+	}
+	return details, nil
+}
 
-	namesOrdering := createNamesOrdering(names)
-	wg, outputChan, errorsChan := startProcessors(namesOrdering, contentsChan, failedFilesChan)
+// resolveEntry returns the tree entry of the one file the name resolves to
+func (g Getter) resolveEntry(treeEntries []*github.TreeEntry, name string) (*github.TreeEntry, error) {
+	paths, failedFiles := g.Resolver().Resolve([]string{name}, entryPaths(treeEntries))
+	if failedFiles != nil {
+		return nil, failedFiles
+	}
+	if len(paths) != 1 {
+		return nil, fmt.Errorf("%s matches %d files, rather than one", name, len(paths))
+	}
+	for _, entry := range treeEntries {
+		if entry.GetPath() == paths[0] {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not present in file tree", paths[0])
+}
 
-	for _, name := range names {
-		namesChan <- name
-		wg.Add(1)
+// fileURL returns the URL of the web page showing the file
+func (g Getter) fileURL(filePath string) string {
+	u := *g.client.BaseURL
+	if u.Host == "api.github.com" {
+		u.Host = "github.com"
 	}
-	wg.Wait()
-	close(namesChan)
-	close(contentsChan)
-	close(failedFilesChan)
+	u.Path = path.Join("/", strings.TrimSuffix(u.Path, "api/v3/"), g.Owner, g.Repository, "blob", g.Branch, filePath)
+	return u.String()
+}
 
-	namedContents := <-outputChan
-	failedFiles := <-errorsChan
-	if failedFiles != nil {
-		err = g.newGetError(failedFiles)
+// Resolve resolves the name to the path of one file
+func (g Getter) Resolve(ctx context.Context, name string) (string, error) {
+	tree, err := g.getTree(ctx)
+	if err != nil {
+		return "", g.newGetError(err)
 	}
-	return namedContents, err
+	entry, err := g.resolveEntry(tree.Entries, name)
+	if err != nil {
+		return "", g.newGetError(err)
+	}
+	return entry.GetPath(), nil
 }
 
-func (g Getter) getBlob(ctx context.Context, pathsToSHAs map[string]string, namesChan chan string, contentsChan chan getignore.NamedContents, failedFilesChan chan getignore.FailedFile) {
-	for name := range namesChan {
-		sha, ok := pathsToSHAs[name]
-		if ok {
-			blobContents, _, err := g.client.Git.GetBlobRaw(ctx, g.Owner, g.Repository, sha)
-			if err != nil {
-				failedFile := getignore.FailedFile{
-					Name:    name,
-					Message: "failed to download",
-					Err:     err,
-				}
-				failedFilesChan <- failedFile
-			} else {
-				nc := getignore.NamedContents{
-					Name:     name,
-					Contents: string(blobContents),
-				}
-				contentsChan <- nc
-			}
-		} else {
-			failedFile := getignore.FailedFile{
-				Name:    name,
-				Message: "not present in file tree",
-			}
-			failedFilesChan <- failedFile
+// History resolves the name to the path of one file, and lists the commits
+// on the branch that changed it, most recent first, up to the limit, if it is
+// positive, paging through them as needed
+func (g Getter) History(ctx context.Context, name string, limit int) (string, []getignore.Commit, error) {
+	filePath, err := g.Resolve(ctx, name)
+	if err != nil {
+		return "", nil, err
+	}
+	options := &github.CommitsListOptions{
+		SHA:         g.Branch,
+		Path:        filePath,
+		ListOptions: github.ListOptions{PerPage: maxCommitsPerPage},
+	}
+	if limit > 0 && limit < maxCommitsPerPage {
+		options.PerPage = limit
+	}
+	var commits []getignore.Commit
+	for {
+		repositoryCommits, resp, err := g.client.Repositories.ListCommits(ctx, g.Owner, g.Repository, options)
+		if err != nil {
+			return "", nil, g.newGetError(getignore.FailedFile{
+				Name:    filePath,
+				Message: "unable to get commit information",
+				Err:     err,
+			})
 		}
+		for _, repositoryCommit := range repositoryCommits {
+			commits = append(commits, newCommit(repositoryCommit))
+		}
+		if limit > 0 && len(commits) >= limit {
+			return filePath, commits[:limit], nil
+		}
+		if resp.NextPage == 0 {
+			return filePath, commits, nil
+		}
+		options.Page = resp.NextPage
+	}
+}
+
+// GetAt gets the contents of the file at the path as of the ref, which may
+// be a branch, tag, or commit
+func (g Getter) GetAt(ctx context.Context, filePath string, ref string) (string, error) {
+	fileContent, _, _, err := g.client.Repositories.GetContents(ctx, g.Owner, g.Repository, filePath, &github.RepositoryContentGetOptions{Ref: ref})
+	if err == nil && fileContent == nil {
+		err = errors.New("not a file")
+	}
+	var contents string
+	if err == nil {
+		contents, err = fileContent.GetContent()
+	}
+	if err != nil {
+		return "", g.newGetError(getignore.FailedFile{
+			Name:    fmt.Sprintf("%s@%s", filePath, ref),
+			Message: "failed to download",
+			Err:     err,
+		})
 	}
+	return contents, nil
+}
+
+// GetBlob gets the contents of the blob with the SHA
+func (g Getter) GetBlob(ctx context.Context, sha string) (string, error) {
+	blobContents, _, err := g.client.Git.GetBlobRaw(ctx, g.Owner, g.Repository, sha)
+	if err != nil {
+		return "", g.newGetError(getignore.FailedFile{
+			Name:    sha,
+			Message: "failed to download",
+			Err:     err,
+		})
+	}
+	return string(blobContents), nil
 }
 
 func (g Getter) newListError(err error) error {
@@ -213,7 +344,7 @@ func (g Getter) newGetError(err error) error {
 func (g Getter) getTree(ctx context.Context) (*github.Tree, error) {
 	branch, _, err := g.client.Repositories.GetBranch(ctx, g.Owner, g.Repository, g.Branch, true)
 	if err != nil {
-		return nil, errors.New("unable to get branch information")
+		return nil, fmt.Errorf("unable to get branch information: %w", err)
 	}
 	sha := branch.GetCommit().GetCommit().GetTree().GetSHA()
 	if sha == "" {
@@ -221,7 +352,7 @@ func (g Getter) getTree(ctx context.Context) (*github.Tree, error) {
 	}
 	tree, _, err := g.client.Git.GetTree(ctx, g.Owner, g.Repository, sha, true)
 	if err != nil {
-		return nil, errors.New("unable to get tree information")
+		return nil, fmt.Errorf("unable to get tree information: %w", err)
 	}
 	return tree, nil
 }
@@ -238,25 +369,22 @@ func (g Getter) filterTreeEntries(treeEntries []*github.TreeEntry) []*github.Tre
 	return entries
 }
 
-func (g Getter) startDownloaders(ctx context.Context, numFilesToDownload int, pathsToSHAs map[string]string) (chan string, chan getignore.NamedContents, chan getignore.FailedFile) {
-	namesChan := make(chan string, numFilesToDownload)
-	maxRequests := min(numFilesToDownload, g.MaxRequests)
-	contentsChan := make(chan getignore.NamedContents, numFilesToDownload)
-	failedFilesChan := make(chan getignore.FailedFile, numFilesToDownload)
-	for i := 0; i < maxRequests; i++ {
-		go g.getBlob(ctx, pathsToSHAs, namesChan, contentsChan, failedFilesChan)
+func newCommit(repositoryCommit *github.RepositoryCommit) getignore.Commit {
+	commit := repositoryCommit.GetCommit()
+	return getignore.Commit{
+		SHA:     repositoryCommit.GetSHA(),
+		Author:  commit.GetAuthor().GetName(),
+		Date:    commit.GetAuthor().GetDate(),
+		Message: commit.GetMessage(),
 	}
-	return namesChan, contentsChan, failedFilesChan
 }
 
-func (g Getter) ensureSuffixes(names []string) []string {
-	paths := make([]string, len(names))
-	for i, name := range names {
-		path := name
-		if filepath.Ext(name) == "" {
-			path = name + g.Suffix
+func entryPaths(entries []*github.TreeEntry) []string {
+	var paths []string
+	for _, entry := range entries {
+		if entry.GetType() == "blob" {
+			paths = append(paths, entry.GetPath())
 		}
-		paths[i] = path
 	}
 	return paths
 }
@@ -270,63 +398,3 @@ func createPathsToSHAs(entries []*github.TreeEntry) map[string]string {
 	}
 	return pathsToSHAs
 }
-
-func min(x int, y int) int {
-	if x <= y {
-		return x
-	}
-	return y
-}
-
-func createNamesOrdering(names []string) map[string]int {
-	namesOrdering := make(map[string]int)
-	for i, name := range names {
-		namesOrdering[name] = i
-	}
-	return namesOrdering
-}
-
-func startProcessors(namesOrdering map[string]int, contentsChan chan getignore.NamedContents, failedFilesChan chan getignore.FailedFile) (*sync.WaitGroup, chan []getignore.NamedContents, chan getignore.FailedFiles) {
-	var wg sync.WaitGroup
-	outputChan := make(chan []getignore.NamedContents)
-	errorsChan := make(chan getignore.FailedFiles)
-	go processContents(contentsChan, namesOrdering, outputChan, &wg)
-	go processErrors(failedFilesChan, errorsChan, &wg)
-	return &wg, outputChan, errorsChan
-}
-
-func processContents(contentsChan chan getignore.NamedContents, namesOrdering map[string]int, outputChannel chan []getignore.NamedContents, wg *sync.WaitGroup) {
-	var allRetrievedContents []getignore.NamedContents
-	for contents := range contentsChan {
-		allRetrievedContents = append(allRetrievedContents, contents)
-		wg.Done()
-	}
-	sort.Sort(&contentsWithOrdering{contents: allRetrievedContents, ordering: namesOrdering})
-	outputChannel <- allRetrievedContents
-}
-
-type contentsWithOrdering struct {
-	contents []getignore.NamedContents
-	ordering map[string]int
-}
-
-func (cwo *contentsWithOrdering) Len() int {
-	return len(cwo.contents)
-}
-
-func (cwo *contentsWithOrdering) Swap(i, j int) {
-	cwo.contents[i], cwo.contents[j] = cwo.contents[j], cwo.contents[i]
-}
-
-func (cwo *contentsWithOrdering) Less(i, j int) bool {
-	return cwo.ordering[cwo.contents[i].Name] < cwo.ordering[cwo.contents[j].Name]
-}
-
-func processErrors(failedFilesChan chan getignore.FailedFile, errorsChan chan getignore.FailedFiles, wg *sync.WaitGroup) {
-	var failedFiles getignore.FailedFiles
-	for failedFile := range failedFilesChan {
-		failedFiles = append(failedFiles, failedFile)
-		wg.Done()
-	}
-	errorsChan <- failedFiles
-}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gotgenes/getignore/pkg/getignore"
@@ -142,6 +143,16 @@ var _ = Describe("Getter", func() {
 					[]string{"Actionscript.gitignore", "Global/Anjuta.gitignore", "community/AWS/SAM.gitignore"},
 					"should return a list of gitignore files",
 				)
+
+				It("should return entries with the SHA and size of each file", func() {
+					entries, err := getter.ListEntries(ctx)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(entries).Should(Equal([]getignore.FileEntry{
+						{Path: "Actionscript.gitignore", SHA: "5d947ca8879f8a9072fe485c566204e3c2929e80", Size: 350},
+						{Path: "Global/Anjuta.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4", Size: 78},
+						{Path: "community/AWS/SAM.gitignore", SHA: "dc9d020aee1ebc1a23c02d80a1c33c0cb35ebaeb", Size: 167},
+					}))
+				})
 			})
 
 			When("the response has additional files", func() {
@@ -532,6 +543,74 @@ var _ = Describe("Getter", func() {
 					Context("the name does not include an extension", func() {
 						assertReturnsExpectedContents("Go")
 					})
+
+					Context("the maximum number of requests is zero", func() {
+						BeforeEach(func() {
+							getter, _ = github.NewGetter(github.WithBaseURL(server.URL()), github.WithMaxRequests(0))
+						})
+
+						assertReturnsExpectedContents("Go")
+					})
+
+					Context("the name differs in case", func() {
+						assertReturnsExpectedContents("go")
+					})
+
+					Context("the name is a pattern", func() {
+						assertReturnsExpectedContents("G*")
+					})
+
+					Context("other files matching the pattern are excluded", func() {
+						BeforeEach(func() {
+							excludes, _ := getignore.ParsePatterns([]string{"Action*"})
+							getter, _ = github.NewGetter(github.WithBaseURL(server.URL()), github.WithResolver(getignore.WithExcludes(excludes)))
+						})
+
+						assertReturnsExpectedContents("*")
+					})
+
+					Context("other files matching the pattern are excluded by name", func() {
+						BeforeEach(func() {
+							getter, _ = github.NewGetter(github.WithBaseURL(server.URL()), github.WithResolver(getignore.WithExcludeNames([]string{"actionscript"})))
+						})
+
+						assertReturnsExpectedContents("*")
+					})
+
+					Context("the name is an alias", func() {
+						BeforeEach(func() {
+							getter, _ = github.NewGetter(
+								github.WithBaseURL(server.URL()),
+								github.WithResolver(getignore.WithAliases(map[string]string{"golang": "Go"})),
+							)
+						})
+
+						assertReturnsExpectedContents("golang")
+					})
+
+					Context("the name is given more than once", func() {
+						It("should return the contents once", func() {
+							nc, _ := getter.Get(ctx, []string{"Go", "go", "Go.gitignore"})
+							Expect(nc).To(HaveLen(1))
+						})
+					})
+
+					Context("the name is misspelled", func() {
+						It("should suggest the matching file", func() {
+							_, err := getter.Get(ctx, []string{"Goo"})
+							Expect(err).Should(MatchError(ContainSubstring(
+								"Goo: not present in file tree (did you mean Go.gitignore?)",
+							)))
+						})
+
+						When("fuzzy matching is enabled", func() {
+							BeforeEach(func() {
+								getter, _ = github.NewGetter(github.WithBaseURL(server.URL()), github.WithResolver(getignore.WithFuzzy(true)))
+							})
+
+							assertReturnsExpectedContents("Goo")
+						})
+					})
 				})
 
 				When("the server errors", func() {
@@ -904,4 +983,331 @@ var _ = Describe("Getter", func() {
 			})
 		})
 	})
+
+	Describe("Describe", func() {
+		var (
+			blobStatusCode    int
+			blobResponse      string
+			commitsStatusCode int
+			commitsResponse   string
+		)
+
+		BeforeEach(func() {
+			blobStatusCode = http.StatusOK
+			blobResponse = "*.o\n*.a\n*.so\n"
+			commitsStatusCode = http.StatusOK
+			commitsResponse = `[
+  {
+    "sha": "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+    "commit": {
+      "author": {
+        "name": "Octo Cat",
+        "date": "2021-10-14T08:30:00Z"
+      },
+      "message": "Add Go workspace file\n\nGo 1.18 introduces go.work files."
+    }
+  }
+]`
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/branches/master"),
+					ghttp.RespondWith(
+						http.StatusOK,
+						`{"commit": {"commit": {"tree": {"sha": "5adf061bdde4dd26889be1e74028b2f54aabc346"}}}}`,
+					),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/git/trees/5adf061bdde4dd26889be1e74028b2f54aabc346"),
+					ghttp.RespondWith(
+						http.StatusOK,
+						`{
+  "tree": [
+    {
+      "path": "Go.gitignore",
+      "type": "blob",
+      "sha": "66fd13c903cac02eb9657cd53fb227823484401d",
+      "size": 14
+    }
+  ]
+}`,
+					),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/git/blobs/66fd13c903cac02eb9657cd53fb227823484401d"),
+					ghttp.RespondWithPtr(&blobStatusCode, &blobResponse),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/commits", "path=Go.gitignore&per_page=1&sha=master"),
+					ghttp.RespondWithPtr(&commitsStatusCode, &commitsResponse),
+				),
+			)
+		})
+
+		It("should return the details of the file", func() {
+			details, err := getter.Describe(ctx, "go")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details).Should(Equal(getignore.FileDetails{
+				FileEntry: getignore.FileEntry{
+					Path: "Go.gitignore",
+					SHA:  "66fd13c903cac02eb9657cd53fb227823484401d",
+					Size: 14,
+				},
+				Contents: "*.o\n*.a\n*.so\n",
+				URL:      server.URL() + "/github/gitignore/blob/master/Go.gitignore",
+				LastCommit: getignore.Commit{
+					SHA:     "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+					Author:  "Octo Cat",
+					Date:    time.Date(2021, 10, 14, 8, 30, 0, 0, time.UTC),
+					Message: "Add Go workspace file\n\nGo 1.18 introduces go.work files.",
+				},
+			}))
+		})
+
+		When("the file has no commits", func() {
+			BeforeEach(func() {
+				commitsResponse = "[]"
+			})
+
+			It("should leave out the last commit", func() {
+				details, err := getter.Describe(ctx, "Go")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.LastCommit).Should(Equal(getignore.Commit{}))
+			})
+		})
+
+		When("the name is not present in the tree", func() {
+			It("should return an error with suggestions", func() {
+				_, err := getter.Describe(ctx, "Goo")
+				Expect(err).Should(MatchError(ContainSubstring("Goo: not present in file tree (did you mean Go.gitignore?)")))
+			})
+		})
+
+		When("the blob request fails", func() {
+			BeforeEach(func() {
+				blobStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, err := getter.Describe(ctx, "Go")
+				Expect(err).Should(MatchError(ContainSubstring("Go.gitignore: failed to download")))
+			})
+		})
+
+		When("the commits request fails", func() {
+			BeforeEach(func() {
+				commitsStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, err := getter.Describe(ctx, "Go")
+				Expect(err).Should(MatchError(ContainSubstring("Go.gitignore: unable to get commit information")))
+			})
+		})
+	})
+
+	Describe("GetBlob", func() {
+		var (
+			blobStatusCode int
+			blobResponse   string
+		)
+
+		BeforeEach(func() {
+			blobStatusCode = http.StatusOK
+			blobResponse = "*.o\n*.a\n*.so\n"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/git/blobs/66fd13c903cac02eb9657cd53fb227823484401d"),
+					ghttp.RespondWithPtr(&blobStatusCode, &blobResponse),
+				),
+			)
+		})
+
+		It("should return the contents of the blob", func() {
+			contents, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal("*.o\n*.a\n*.so\n"))
+		})
+
+		When("the blob request fails", func() {
+			BeforeEach(func() {
+				blobStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+				Expect(err).Should(MatchError(ContainSubstring("66fd13c903cac02eb9657cd53fb227823484401d: failed to download")))
+			})
+		})
+	})
+
+	Describe("History", func() {
+		var (
+			commitsStatusCode int
+			commitsResponse   string
+			commitsQuery      string
+		)
+
+		BeforeEach(func() {
+			commitsStatusCode = http.StatusOK
+			commitsQuery = "path=Go.gitignore&per_page=2&sha=master"
+			commitsResponse = `[
+  {
+    "sha": "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+    "commit": {
+      "author": {"name": "Octo Cat", "date": "2021-10-14T08:30:00Z"},
+      "message": "Add Go workspace file"
+    }
+  },
+  {
+    "sha": "7e2b0b0d7f0a6e8f0d1c2b3a4f5e6d7c8b9a0f1e",
+    "commit": {
+      "author": {"name": "Mona Lisa", "date": "2020-01-02T10:00:00Z"},
+      "message": "Ignore test binaries"
+    }
+  }
+]`
+		})
+
+		JustBeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/branches/master"),
+					ghttp.RespondWith(
+						http.StatusOK,
+						`{"commit": {"commit": {"tree": {"sha": "5adf061bdde4dd26889be1e74028b2f54aabc346"}}}}`,
+					),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/git/trees/5adf061bdde4dd26889be1e74028b2f54aabc346"),
+					ghttp.RespondWith(
+						http.StatusOK,
+						`{"tree": [{"path": "Go.gitignore", "type": "blob", "sha": "66fd13c903cac02eb9657cd53fb227823484401d", "size": 14}]}`,
+					),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/commits", commitsQuery),
+					ghttp.RespondWithPtr(&commitsStatusCode, &commitsResponse),
+				),
+			)
+		})
+
+		It("should list the commits that changed the file", func() {
+			filePath, commits, err := getter.History(ctx, "go", 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(filePath).Should(Equal("Go.gitignore"))
+			Expect(commits).Should(Equal([]getignore.Commit{
+				{
+					SHA:     "b0012e4930d0a8c350254a3caeedf7441ea286a3",
+					Author:  "Octo Cat",
+					Date:    time.Date(2021, 10, 14, 8, 30, 0, 0, time.UTC),
+					Message: "Add Go workspace file",
+				},
+				{
+					SHA:     "7e2b0b0d7f0a6e8f0d1c2b3a4f5e6d7c8b9a0f1e",
+					Author:  "Mona Lisa",
+					Date:    time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+					Message: "Ignore test binaries",
+				},
+			}))
+		})
+
+		When("there are more commits than fit in a page", func() {
+			pageOfCommits := func(first int, count int) string {
+				commits := make([]string, count)
+				for i := range commits {
+					commits[i] = fmt.Sprintf(`{"sha": "%040x", "commit": {"author": {"name": "Octo Cat", "date": "2021-10-14T08:30:00Z"}, "message": "Change %d"}}`, first+i, first+i)
+				}
+				return "[" + strings.Join(commits, ",") + "]"
+			}
+
+			BeforeEach(func() {
+				commitsQuery = "path=Go.gitignore&per_page=100&sha=master"
+				commitsResponse = pageOfCommits(0, 100)
+			})
+
+			JustBeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/commits", commitsQuery),
+					ghttp.RespondWith(http.StatusOK, commitsResponse, http.Header{
+						"Link": {fmt.Sprintf(`<%s/api/v3/repos/github/gitignore/commits?page=2>; rel="next"`, server.URL())},
+					}),
+				))
+			})
+
+			It("should page through the commits up to the limit", func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/commits", "page=2&path=Go.gitignore&per_page=100&sha=master"),
+					ghttp.RespondWith(http.StatusOK, pageOfCommits(100, 100)),
+				))
+				_, commits, err := getter.History(ctx, "Go", 150)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(commits).Should(HaveLen(150))
+				Expect(commits[149].Message).Should(Equal("Change 149"))
+			})
+
+			It("should stop at the last page", func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/commits", "page=2&path=Go.gitignore&per_page=100&sha=master"),
+					ghttp.RespondWith(http.StatusOK, pageOfCommits(100, 20)),
+				))
+				_, commits, err := getter.History(ctx, "Go", 500)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(commits).Should(HaveLen(120))
+				Expect(server.ReceivedRequests()).Should(HaveLen(4))
+			})
+		})
+
+		When("the name is not present in the tree", func() {
+			It("should return an error with suggestions", func() {
+				_, _, err := getter.History(ctx, "Goo", 2)
+				Expect(err).Should(MatchError(ContainSubstring("Goo: not present in file tree (did you mean Go.gitignore?)")))
+			})
+		})
+
+		When("the commits request fails", func() {
+			BeforeEach(func() {
+				commitsStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, _, err := getter.History(ctx, "Go", 2)
+				Expect(err).Should(MatchError(ContainSubstring("Go.gitignore: unable to get commit information")))
+			})
+		})
+	})
+
+	Describe("GetAt", func() {
+		var (
+			contentsStatusCode int
+			contentsResponse   string
+		)
+
+		BeforeEach(func() {
+			contentsStatusCode = http.StatusOK
+			contentsResponse = `{"type": "file", "encoding": "base64", "path": "Go.gitignore", "content": "Ki5vCiouYQo="}`
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/repos/github/gitignore/contents/Go.gitignore", "ref=b0012e4"),
+					ghttp.RespondWithPtr(&contentsStatusCode, &contentsResponse),
+				),
+			)
+		})
+
+		It("should return the contents of the file at the ref", func() {
+			contents, err := getter.GetAt(ctx, "Go.gitignore", "b0012e4")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal("*.o\n*.a\n"))
+		})
+
+		When("the contents request fails", func() {
+			BeforeEach(func() {
+				contentsStatusCode = http.StatusNotFound
+			})
+
+			It("should return an error", func() {
+				_, err := getter.GetAt(ctx, "Go.gitignore", "b0012e4")
+				Expect(err).Should(MatchError(ContainSubstring("Go.gitignore@b0012e4: failed to download")))
+			})
+		})
+	})
 })
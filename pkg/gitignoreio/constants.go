@@ -0,0 +1,23 @@
+package gitignoreio
+
+const (
+	// BaseURL is the URL of the gitignore.io API hosted by Toptal, without the
+	// API path
+	BaseURL = "https://www.toptal.com/developers/gitignore"
+	// TemplateSuffix identifies templates in the Toptal templates repository
+	TemplateSuffix = ".gitignore"
+	// PatchSuffix identifies patches, whose contents follow those of the
+	// template of the same name
+	PatchSuffix = ".patch"
+	// StackSuffix identifies stacks, which list the names of the templates
+	// they bundle
+	StackSuffix = ".stack"
+
+	apiPath = "/api"
+	// errorPrefix starts the contents the API returns for undefined names
+	errorPrefix = "#!! ERROR"
+)
+
+// generatedPrefixes start the comments the API adds before and after the
+// contents of templates
+var generatedPrefixes = []string{"# Created by ", "# Edit at ", "# End of "}
@@ -0,0 +1,199 @@
+package gitignoreio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = getignore.DefaultMaxRequests
+
+// Getter lists and gets templates using a gitignore.io compatible API, such as
+// the one hosted by Toptal. Templates are identified by their keys, e.g.,
+// macos or visualstudiocode, rather than by paths.
+type Getter struct {
+	client *http.Client
+	// BaseURL is the URL of the API, without the API path
+	BaseURL     string
+	MaxRequests int
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	client      *http.Client
+	baseURL     string
+	maxRequests int
+	resolver    getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		client:      http.DefaultClient,
+		baseURL:     BaseURL,
+		maxRequests: DefaultMaxRequests,
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.baseURL == "" {
+		params.baseURL = BaseURL
+	}
+	if _, err := url.Parse(params.baseURL); err != nil {
+		return Getter{}, fmt.Errorf("invalid gitignore.io base URL: %w", err)
+	}
+	// templates are named without a suffix
+	params.resolver.Suffix = ""
+	return Getter{
+		client:           params.client,
+		BaseURL:          strings.TrimSuffix(params.baseURL, "/"),
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithClient sets the HTTP client for the Getter
+func WithClient(client *http.Client) GetterOption {
+	return func(p *getterParams) {
+		p.client = client
+	}
+}
+
+// WithBaseURL sets the URL of the API, without the API path, for the Getter
+func WithBaseURL(baseURL string) GetterOption {
+	return func(p *getterParams) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithMaxRequests sets the number of maximum concurrent HTTP requests
+func WithMaxRequests(max int) GetterOption {
+	return func(p *getterParams) {
+		p.maxRequests = max
+	}
+}
+
+// WithResolver sets how the Getter resolves names to templates
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// List returns an array of the keys of the templates.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries whose paths are the keys of the
+// templates. The API does not report the SHAs or sizes of templates, so those
+// are left empty.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	keys, err := g.listKeys(ctx)
+	if err != nil {
+		return nil, g.newListError(err)
+	}
+	entries := make([]getignore.FileEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = getignore.FileEntry{Path: key}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the templates with the given names,
+// without the comments the API adds around them
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	keys, err := g.listKeys(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	keys, unresolvedFiles := g.Resolver().Resolve(names, keys)
+	namedContents, failedFiles := getignore.DownloadFiles(ctx, keys, g.MaxRequests, g.getTemplate)
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+func (g Getter) newListError(err error) error {
+	return fmt.Errorf("error listing templates of %s: %w", g.BaseURL, err)
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting templates from %s: %w", g.BaseURL, err)
+}
+
+// listKeys lists the keys of the templates, which the API separates by commas
+// or, as requested, by lines
+func (g Getter) listKeys(ctx context.Context) ([]string, error) {
+	body, err := g.get(ctx, g.BaseURL+apiPath+"/list?format=lines")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the list of templates: %w", err)
+	}
+	fields := strings.FieldsFunc(string(body), func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+	var keys []string
+	for _, field := range fields {
+		if key := strings.TrimSpace(field); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// getTemplate gets the contents of the template with the key
+func (g Getter) getTemplate(ctx context.Context, key string) (string, error) {
+	body, err := g.get(ctx, g.BaseURL+apiPath+"/"+url.PathEscape(key))
+	if err != nil {
+		return "", err
+	}
+	contents := string(body)
+	if strings.HasPrefix(strings.TrimSpace(contents), errorPrefix) {
+		return "", errors.New(strings.TrimSpace(contents))
+	}
+	return stripGenerated(contents), nil
+}
+
+// stripGenerated removes the comments the API adds before and after the
+// contents of templates, along with the blank lines around them
+func stripGenerated(contents string) string {
+	var lines []string
+	for _, line := range strings.Split(contents, "\n") {
+		if !isGenerated(line) {
+			lines = append(lines, line)
+		}
+	}
+	stripped := strings.Trim(strings.Join(lines, "\n"), "\n")
+	if stripped == "" {
+		return ""
+	}
+	return stripped + "\n"
+}
+
+func isGenerated(line string) bool {
+	for _, prefix := range generatedPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// get requests the URL, returning the body of the response
+func (g Getter) get(ctx context.Context, u string) ([]byte, error) {
+	body, _, err := getignore.HTTPGet(ctx, g.client, u, nil)
+	return body, err
+}
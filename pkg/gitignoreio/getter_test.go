@@ -0,0 +1,110 @@
+package gitignoreio_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitignoreio"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx               context.Context
+		server            *ghttp.Server
+		getter            gitignoreio.Getter
+		expectedUserAgent = []string{fmt.Sprintf("getignore/%s", getignore.Version)}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+		getter, _ = gitignoreio.NewGetter(gitignoreio.WithBaseURL(server.URL() + "/"))
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/list", "format=lines"),
+			ghttp.VerifyHeader(http.Header{
+				"User-Agent": expectedUserAgent,
+			}),
+			ghttp.RespondWith(http.StatusOK, "go\nmacos\nvisualstudiocode\n"),
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGetter", func() {
+		It("should default to the API hosted by Toptal", func() {
+			getter, err := gitignoreio.NewGetter()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getter.BaseURL).Should(Equal("https://www.toptal.com/developers/gitignore"))
+		})
+
+		It("should resolve templates without a suffix", func() {
+			getter, err := gitignoreio.NewGetter(gitignoreio.WithResolver(getignore.WithSuffix(".gitignore"), getignore.WithFuzzy(true)))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getter.Suffix).Should(BeEmpty())
+			Expect(getter.Fuzzy).Should(BeTrue())
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the keys of the templates", func() {
+			keys, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(keys).Should(Equal([]string{"go", "macos", "visualstudiocode"}))
+		})
+
+		It("should list keys separated by commas", func() {
+			server.SetHandler(0, ghttp.RespondWith(http.StatusOK, "go,macos\nvisualstudiocode\n"))
+			keys, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(keys).Should(Equal([]string{"go", "macos", "visualstudiocode"}))
+		})
+
+		It("should return an error when the server errors", func() {
+			server.SetHandler(0, ghttp.RespondWith(http.StatusServiceUnavailable, ""))
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(fmt.Sprintf("error listing templates of %s: unable to get the list of templates: GET %s/api/list?format=lines: 503 Service Unavailable", server.URL(), server.URL())))
+		})
+	})
+
+	Describe("Get", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/macos", ghttp.RespondWith(http.StatusOK, `# Created by https://www.toptal.com/developers/gitignore/api/macos
+# Edit at https://www.toptal.com/developers/gitignore?templates=macos
+
+### macOS ###
+# General
+.DS_Store
+
+# End of https://www.toptal.com/developers/gitignore/api/macos
+`))
+			server.RouteToHandler("GET", "/api/go", ghttp.RespondWith(http.StatusOK, "\n### Go ###\n*.o\n"))
+		})
+
+		It("should get the templates in the order of the names, without the generated comments", func() {
+			contents, err := getter.Get(ctx, []string{"macOS", "go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "macos", Contents: "### macOS ###\n# General\n.DS_Store\n"},
+				{Name: "go", Contents: "### Go ###\n*.o\n"},
+			}))
+		})
+
+		It("should suggest templates for names not present", func() {
+			_, err := getter.Get(ctx, []string{"visualstudio"})
+			Expect(err).Should(MatchError(ContainSubstring("did you mean visualstudiocode?")))
+		})
+
+		It("should report templates the API does not define", func() {
+			server.RouteToHandler("GET", "/api/go", ghttp.RespondWith(http.StatusOK, "#!! ERROR: go is undefined. Use list command to see defined gitignore types !!#\n"))
+			_, err := getter.Get(ctx, []string{"go"})
+			Expect(err).Should(MatchError(fmt.Sprintf("error getting templates from %s: failed to get the following files: go\ngo: failed to download\n", server.URL())))
+		})
+	})
+})
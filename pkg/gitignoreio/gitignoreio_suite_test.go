@@ -0,0 +1,13 @@
+package gitignoreio_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGitignoreio(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gitignore.io Suite")
+}
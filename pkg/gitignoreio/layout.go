@@ -0,0 +1,263 @@
+package gitignoreio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// Layout gets templates from a repository laid out like the Toptal templates
+// repository, which gitignore.io serves. There, the contents of a template,
+// NAME.gitignore, are followed by those of its patch, NAME.patch, if any, and
+// a stack, NAME.stack, lists the names of the templates it bundles, one per
+// line.
+//
+// Names resolve to templates and stacks by their paths without the suffix,
+// e.g., templates/Go or Go. Source must list every file of the repository,
+// rather than only those with a suffix, and get files by their exact paths.
+type Layout struct {
+	Source       getignore.Source
+	Fuzzy        bool
+	Aliases      map[string]string
+	Excludes     []getignore.Pattern
+	ExcludeNames []string
+}
+
+// layoutFiles holds the paths of the files of a repository by their roles
+type layoutFiles struct {
+	// templates and stacks map the paths without the suffix to the paths
+	templates map[string]string
+	stacks    map[string]string
+	patches   map[string]bool
+}
+
+// List returns an array of the paths of the templates and stacks.
+func (l Layout) List(ctx context.Context) ([]string, error) {
+	entries, err := l.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the templates and stacks.
+// As their contents are assembled from several files, the SHAs and sizes of
+// the entries are left empty.
+func (l Layout) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	files, err := l.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []getignore.FileEntry
+	for _, key := range files.keys() {
+		entries = append(entries, getignore.FileEntry{Path: files.path(key)})
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the templates, with their patches, and
+// stacks with the given names
+func (l Layout) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	files, err := l.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, failedFiles := l.resolver().Resolve(trimSuffixes(names), files.keys())
+	members, stackFailedFiles, err := l.getStackMembers(ctx, files, keys)
+	if err != nil {
+		return nil, err
+	}
+	failedFiles = append(failedFiles, stackFailedFiles...)
+	var paths []string
+	for _, key := range keys {
+		if _, ok := files.templates[key]; ok {
+			paths = append(paths, files.templatePaths(key)...)
+		}
+		for _, member := range members[key] {
+			paths = append(paths, files.templatePaths(member)...)
+		}
+	}
+	contents, err := l.getContents(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	var namedContents []getignore.NamedContents
+	for _, key := range keys {
+		var parts []string
+		if _, ok := files.templates[key]; ok {
+			parts = contents.parts(files.templatePaths(key))
+		}
+		for _, member := range members[key] {
+			parts = append(parts, joinContents(contents.parts(files.templatePaths(member))))
+		}
+		namedContents = append(namedContents, getignore.NamedContents{
+			Name:     files.path(key),
+			Contents: joinContents(parts),
+		})
+	}
+	if failedFiles != nil {
+		err = fmt.Errorf("error getting templates: %w", failedFiles)
+	}
+	return namedContents, err
+}
+
+func (l Layout) resolver() getignore.Resolver {
+	return getignore.Resolver{
+		Aliases:      l.Aliases,
+		Fuzzy:        l.Fuzzy,
+		Excludes:     l.Excludes,
+		ExcludeNames: trimSuffixes(l.ExcludeNames),
+	}
+}
+
+func (l Layout) listFiles(ctx context.Context) (layoutFiles, error) {
+	entries, err := l.Source.ListEntries(ctx)
+	if err != nil {
+		return layoutFiles{}, err
+	}
+	files := layoutFiles{
+		templates: make(map[string]string),
+		stacks:    make(map[string]string),
+		patches:   make(map[string]bool),
+	}
+	for _, entry := range entries {
+		switch {
+		case strings.HasSuffix(entry.Path, TemplateSuffix):
+			files.templates[strings.TrimSuffix(entry.Path, TemplateSuffix)] = entry.Path
+		case strings.HasSuffix(entry.Path, StackSuffix):
+			files.stacks[strings.TrimSuffix(entry.Path, StackSuffix)] = entry.Path
+		case strings.HasSuffix(entry.Path, PatchSuffix):
+			files.patches[entry.Path] = true
+		}
+	}
+	return files, nil
+}
+
+// getStackMembers gets the stacks among the keys, returning the keys of the
+// templates each bundles, and a FailedFile for each name in a stack that is
+// not a template
+func (l Layout) getStackMembers(ctx context.Context, files layoutFiles, keys []string) (map[string][]string, getignore.FailedFiles, error) {
+	var paths []string
+	for _, key := range keys {
+		if files.isStack(key) {
+			paths = append(paths, files.stacks[key])
+		}
+	}
+	contents, err := l.getContents(ctx, paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	templateKeys := make([]string, 0, len(files.templates))
+	for key := range files.templates {
+		templateKeys = append(templateKeys, key)
+	}
+	var failedFiles getignore.FailedFiles
+	members := make(map[string][]string)
+	for _, key := range keys {
+		if !files.isStack(key) {
+			continue
+		}
+		names := getignore.ParseNamesFile(strings.NewReader(contents[files.stacks[key]]))
+		resolved, unresolvedFiles := getignore.Resolver{}.Resolve(trimSuffixes(names), templateKeys)
+		for _, failedFile := range unresolvedFiles {
+			failedFile.Message = fmt.Sprintf("%s, listed by stack %s", failedFile.Message, files.stacks[key])
+			failedFiles = append(failedFiles, failedFile)
+		}
+		members[key] = resolved
+	}
+	return members, failedFiles, nil
+}
+
+// layoutContents maps the paths of files to their contents
+type layoutContents map[string]string
+
+// getContents gets the contents of the files at the paths
+func (l Layout) getContents(ctx context.Context, paths []string) (layoutContents, error) {
+	contents := make(layoutContents)
+	if len(paths) == 0 {
+		return contents, nil
+	}
+	namedContents, err := l.Source.Get(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, nc := range namedContents {
+		contents[nc.Name] = nc.Contents
+	}
+	return contents, nil
+}
+
+// parts returns the contents of the files at the paths
+func (c layoutContents) parts(paths []string) []string {
+	parts := make([]string, len(paths))
+	for i, p := range paths {
+		parts[i] = c[p]
+	}
+	return parts
+}
+
+// keys returns the sorted paths, without the suffix, of the templates and the
+// stacks, leaving out stacks with the same name as a template
+func (f layoutFiles) keys() []string {
+	keys := make([]string, 0, len(f.templates)+len(f.stacks))
+	for key := range f.templates {
+		keys = append(keys, key)
+	}
+	for key := range f.stacks {
+		if f.isStack(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f layoutFiles) isStack(key string) bool {
+	_, isTemplate := f.templates[key]
+	_, isStack := f.stacks[key]
+	return isStack && !isTemplate
+}
+
+// path returns the path of the template or stack with the key
+func (f layoutFiles) path(key string) string {
+	if p, ok := f.templates[key]; ok {
+		return p
+	}
+	return f.stacks[key]
+}
+
+// templatePaths returns the paths of the template with the key and of its
+// patch, if any
+func (f layoutFiles) templatePaths(key string) []string {
+	paths := []string{f.templates[key]}
+	if patch := key + PatchSuffix; f.patches[patch] {
+		paths = append(paths, patch)
+	}
+	return paths
+}
+
+// trimSuffixes removes the suffixes of templates and stacks from the names
+func trimSuffixes(names []string) []string {
+	trimmed := make([]string, len(names))
+	for i, name := range names {
+		trimmed[i] = strings.TrimSuffix(strings.TrimSuffix(name, TemplateSuffix), StackSuffix)
+	}
+	return trimmed
+}
+
+// joinContents joins the contents of files, separating them by a blank line
+func joinContents(parts []string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.Trim(part, "\n"); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return ""
+	}
+	return strings.Join(nonEmpty, "\n\n") + "\n"
+}
@@ -0,0 +1,115 @@
+package gitignoreio_test
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitignoreio"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSource gets files by their exact paths from a map of the paths to their
+// contents
+type fakeSource map[string]string
+
+func (s fakeSource) List(ctx context.Context) ([]string, error) {
+	entries, err := s.ListEntries(ctx)
+	return getignore.EntryPaths(entries), err
+}
+
+func (s fakeSource) ListEntries(context.Context) ([]getignore.FileEntry, error) {
+	var entries []getignore.FileEntry
+	for p := range s {
+		entries = append(entries, getignore.FileEntry{Path: p})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+func (s fakeSource) Get(_ context.Context, names []string) ([]getignore.NamedContents, error) {
+	var namedContents []getignore.NamedContents
+	for _, name := range names {
+		contents, ok := s[name]
+		Expect(ok).Should(BeTrue(), "unexpected path %s", name)
+		namedContents = append(namedContents, getignore.NamedContents{Name: name, Contents: contents})
+	}
+	return namedContents, nil
+}
+
+var _ = Describe("Layout", func() {
+	var (
+		ctx    context.Context
+		layout gitignoreio.Layout
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		layout = gitignoreio.Layout{Source: fakeSource{
+			"README.md":                 "# Templates\n",
+			"templates/Go.gitignore":    "### Go ###\n*.o\n",
+			"templates/Go.patch":        "### Go Patch ###\n/vendor/\n",
+			"templates/Node.gitignore":  "### Node ###\nnode_modules/\n",
+			"templates/macOS.gitignore": "### macOS ###\n.DS_Store\n",
+			"templates/MEAN.stack":      "# MongoDB, Express, Angular, and Node\nNode\nmacos\n",
+		}}
+	})
+
+	Describe("List", func() {
+		It("should list the templates and stacks", func() {
+			files, err := layout.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{
+				"templates/Go.gitignore",
+				"templates/MEAN.stack",
+				"templates/Node.gitignore",
+				"templates/macOS.gitignore",
+			}))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should follow templates with their patches", func() {
+			contents, err := layout.Get(ctx, []string{"go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "templates/Go.gitignore", Contents: "### Go ###\n*.o\n\n### Go Patch ###\n/vendor/\n"},
+			}))
+		})
+
+		It("should bundle the templates of stacks", func() {
+			contents, err := layout.Get(ctx, []string{"MEAN", "templates/Node.gitignore"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "templates/MEAN.stack", Contents: "### Node ###\nnode_modules/\n\n### macOS ###\n.DS_Store\n"},
+				{Name: "templates/Node.gitignore", Contents: "### Node ###\nnode_modules/\n"},
+			}))
+		})
+
+		It("should leave out excluded names", func() {
+			layout.ExcludeNames = []string{"Go.gitignore"}
+			contents, err := layout.Get(ctx, []string{"templates/*"})
+			Expect(err).ShouldNot(HaveOccurred())
+			var names []string
+			for _, nc := range contents {
+				names = append(names, nc.Name)
+			}
+			Expect(names).Should(Equal([]string{"templates/MEAN.stack", "templates/Node.gitignore", "templates/macOS.gitignore"}))
+		})
+
+		It("should report names listed by stacks that are not templates", func() {
+			layout.Source.(fakeSource)["templates/MEAN.stack"] = "Node\nExpress\n"
+			contents, err := layout.Get(ctx, []string{"MEAN"})
+			Expect(err).Should(MatchError("error getting templates: failed to get the following files: Express\nExpress: not present in file tree, listed by stack templates/MEAN.stack\n"))
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "templates/MEAN.stack", Contents: "### Node ###\nnode_modules/\n"},
+			}))
+		})
+
+		It("should suggest templates for names not present", func() {
+			_, err := layout.Get(ctx, []string{"Nod"})
+			Expect(err).Should(MatchError(ContainSubstring("did you mean templates/Node?")))
+		})
+	})
+})
@@ -0,0 +1,25 @@
+package gitignoreio
+
+import "github.com/gotgenes/getignore/pkg/getignore"
+
+// prefixSource formats and parses sources giving the base URL of an API
+var prefixSource = getignore.PrefixSource{Prefix: "gitignoreio+", Location: "the base URL of a gitignore.io compatible API"}
+
+// FormatSource returns the source for the API at the base URL, e.g.,
+// gitignoreio+https://www.toptal.com/developers/gitignore, for recording in
+// banners and lock files
+func FormatSource(baseURL string) string {
+	return prefixSource.Format(baseURL)
+}
+
+// IsSource reports whether the source is a gitignore.io compatible API, as
+// given by FormatSource
+func IsSource(source string) bool {
+	return prefixSource.Is(source)
+}
+
+// ParseSource parses a source given by FormatSource into the base URL of the
+// API
+func ParseSource(source string) (string, error) {
+	return prefixSource.Parse(source)
+}
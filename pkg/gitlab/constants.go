@@ -0,0 +1,9 @@
+package gitlab
+
+const (
+	BaseURL = "https://gitlab.com"
+	Suffix  = ".gitignore"
+
+	apiPath      = "/api/v4"
+	treePageSize = 100
+)
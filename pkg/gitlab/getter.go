@@ -0,0 +1,255 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = getignore.DefaultMaxRequests
+
+// Getter lists and gets files using the GitLab repository tree API.
+type Getter struct {
+	client *http.Client
+	// BaseURL is the URL of the GitLab instance, without the API path
+	BaseURL string
+	// Project is the path, e.g., group/subgroup/project, or the ID of the
+	// project
+	Project string
+	// Ref is the branch, tag, or commit to list files at, or empty for the
+	// default branch of the project
+	Ref         string
+	Token       string
+	MaxRequests int
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	client      *http.Client
+	baseURL     string
+	project     string
+	ref         string
+	token       string
+	maxRequests int
+	resolver    getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		client:      http.DefaultClient,
+		baseURL:     BaseURL,
+		resolver:    getignore.ResolverSettings{Suffix: Suffix},
+		maxRequests: DefaultMaxRequests,
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.project == "" {
+		return Getter{}, errors.New("a GitLab project path or ID is required")
+	}
+	if _, err := url.Parse(params.baseURL); err != nil {
+		return Getter{}, fmt.Errorf("invalid GitLab base URL: %w", err)
+	}
+	return Getter{
+		client:           params.client,
+		BaseURL:          strings.TrimSuffix(params.baseURL, "/"),
+		Project:          params.project,
+		Ref:              params.ref,
+		Token:            params.token,
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithClient sets the HTTP client for the Getter
+func WithClient(client *http.Client) GetterOption {
+	return func(p *getterParams) {
+		p.client = client
+	}
+}
+
+// WithBaseURL sets the URL of the GitLab instance for the Getter
+func WithBaseURL(baseURL string) GetterOption {
+	return func(p *getterParams) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithProject sets the path or ID of the project for the Getter
+func WithProject(project string) GetterOption {
+	return func(p *getterParams) {
+		p.project = project
+	}
+}
+
+// WithRef sets the branch, tag, or commit for the Getter
+func WithRef(ref string) GetterOption {
+	return func(p *getterParams) {
+		p.ref = ref
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// WithToken sets the private, personal, or project access token to
+// authenticate with
+func WithToken(token string) GetterOption {
+	return func(p *getterParams) {
+		p.token = token
+	}
+}
+
+// WithMaxRequests sets the number of maximum concurrent HTTP requests
+func WithMaxRequests(max int) GetterOption {
+	return func(p *getterParams) {
+		p.maxRequests = max
+	}
+}
+
+// treeEntry is an entry of the repository tree of a project
+type treeEntry struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix. The tree API does not report sizes, so the sizes of
+// the entries are zero.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	tree, err := g.getTree(ctx)
+	if err != nil {
+		return nil, g.newListError(err)
+	}
+	var entries []getignore.FileEntry
+	for _, entry := range tree {
+		if entry.Type == "blob" && strings.HasSuffix(entry.Path, g.Suffix) {
+			entries = append(entries, getignore.FileEntry{Path: entry.Path, SHA: entry.ID})
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files downloaded from the given names
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	tree, err := g.getTree(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	pathsToSHAs := make(map[string]string)
+	var paths []string
+	for _, entry := range tree {
+		if entry.Type == "blob" {
+			pathsToSHAs[entry.Path] = entry.ID
+			paths = append(paths, entry.Path)
+		}
+	}
+	names, unresolvedFiles := g.Resolver().Resolve(names, paths)
+	namedContents, failedFiles := getignore.DownloadFiles(ctx, names, g.MaxRequests, func(ctx context.Context, name string) (string, error) {
+		return g.getBlob(ctx, pathsToSHAs[name])
+	})
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+// GetBlob gets the contents of the blob with the SHA
+func (g Getter) GetBlob(ctx context.Context, sha string) (string, error) {
+	contents, err := g.getBlob(ctx, sha)
+	if err != nil {
+		return "", g.newGetError(getignore.FailedFile{
+			Name:    sha,
+			Message: "failed to download",
+			Err:     err,
+		})
+	}
+	return contents, nil
+}
+
+func (g Getter) newListError(err error) error {
+	return fmt.Errorf("error listing contents of %s at %s: %w", g.Project, g.refName(), err)
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s at %s: %w", g.Project, g.refName(), err)
+}
+
+// refName names the ref for messages
+func (g Getter) refName() string {
+	if g.Ref == "" {
+		return "the default branch"
+	}
+	return g.Ref
+}
+
+// getTree gets all the entries of the repository tree, following the pages
+// of results
+func (g Getter) getTree(ctx context.Context) ([]treeEntry, error) {
+	var tree []treeEntry
+	query := url.Values{
+		"recursive": {"true"},
+		"per_page":  {strconv.Itoa(treePageSize)},
+	}
+	if g.Ref != "" {
+		query.Set("ref", g.Ref)
+	}
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		body, header, err := g.get(ctx, "repository/tree", query)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get tree information: %w", err)
+		}
+		var entries []treeEntry
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return nil, fmt.Errorf("unable to get tree information: %w", err)
+		}
+		tree = append(tree, entries...)
+		page = header.Get("X-Next-Page")
+	}
+	return tree, nil
+}
+
+func (g Getter) getBlob(ctx context.Context, sha string) (string, error) {
+	body, _, err := g.get(ctx, "repository/blobs/"+url.PathEscape(sha)+"/raw", nil)
+	return string(body), err
+}
+
+// get requests the resource of the project with the query, returning the body
+// and headers of the response
+func (g Getter) get(ctx context.Context, resource string, query url.Values) ([]byte, http.Header, error) {
+	u := fmt.Sprintf("%s%s/projects/%s/%s", g.BaseURL, apiPath, url.PathEscape(g.Project), resource)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return getignore.HTTPGet(ctx, g.client, u, func(req *http.Request) {
+		if g.Token != "" {
+			req.Header.Set("PRIVATE-TOKEN", g.Token)
+		}
+	})
+}
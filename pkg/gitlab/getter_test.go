@@ -0,0 +1,251 @@
+package gitlab_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitlab"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const (
+	treePath       = "/api/v4/projects/acme/templates/repository/tree"
+	goBlobPath     = "/api/v4/projects/acme/templates/repository/blobs/66fd13c903cac02eb9657cd53fb227823484401d/raw"
+	anjutaBlobPath = "/api/v4/projects/acme/templates/repository/blobs/20dd42c53e6f0df8233fee457b664d443ee729f4/raw"
+
+	treeResponse = `[
+  {
+	"id": "45f58ef9211cc06f3ef86585c7ecb1b3d52fd4f9",
+	"name": "Global",
+	"type": "tree",
+	"path": "Global",
+	"mode": "040000"
+  },
+  {
+	"id": "4009e0bc8b07582c19fa761810c9f3741ab76597",
+	"name": "README.md",
+	"type": "blob",
+	"path": "README.md",
+	"mode": "100644"
+  },
+  {
+	"id": "20dd42c53e6f0df8233fee457b664d443ee729f4",
+	"name": "Anjuta.gitignore",
+	"type": "blob",
+	"path": "Global/Anjuta.gitignore",
+	"mode": "100644"
+  }
+]`
+	nextTreeResponse = `[
+  {
+	"id": "66fd13c903cac02eb9657cd53fb227823484401d",
+	"name": "Go.gitignore",
+	"type": "blob",
+	"path": "Go.gitignore",
+	"mode": "100644"
+  }
+]`
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx               context.Context
+		server            *ghttp.Server
+		getter            gitlab.Getter
+		expectedUserAgent = []string{fmt.Sprintf("getignore/%s", getignore.Version)}
+	)
+
+	// appendTreeHandlers responds to the requests for the two pages of the
+	// repository tree
+	appendTreeHandlers := func(query string) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", treePath, "page=1&per_page=100&recursive=true"+query),
+				ghttp.VerifyHeader(http.Header{
+					"User-Agent": expectedUserAgent,
+				}),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.URL.EscapedPath()).Should(Equal("/api/v4/projects/acme%2Ftemplates/repository/tree"))
+				},
+				ghttp.RespondWith(http.StatusOK, treeResponse, http.Header{"X-Next-Page": {"2"}}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", treePath, "page=2&per_page=100&recursive=true"+query),
+				ghttp.RespondWith(http.StatusOK, nextTreeResponse, http.Header{"X-Next-Page": {""}}),
+			),
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+		getter, _ = gitlab.NewGetter(gitlab.WithBaseURL(server.URL()), gitlab.WithProject("acme/templates"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGetter", func() {
+		It("should require a project", func() {
+			_, err := gitlab.NewGetter()
+			Expect(err).Should(MatchError("a GitLab project path or ID is required"))
+		})
+
+		It("should default to GitLab.com", func() {
+			getter, err := gitlab.NewGetter(gitlab.WithProject("1234"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getter.BaseURL).Should(Equal("https://gitlab.com"))
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			appendTreeHandlers("")
+		})
+
+		It("should list the files with the suffix from every page", func() {
+			files, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{"Global/Anjuta.gitignore", "Go.gitignore"}))
+		})
+
+		It("should return entries with the SHA of each file", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Global/Anjuta.gitignore", SHA: "20dd42c53e6f0df8233fee457b664d443ee729f4"},
+				{Path: "Go.gitignore", SHA: "66fd13c903cac02eb9657cd53fb227823484401d"},
+			}))
+		})
+	})
+
+	Describe("List at a ref with a token", func() {
+		BeforeEach(func() {
+			getter, _ = gitlab.NewGetter(
+				gitlab.WithBaseURL(server.URL()),
+				gitlab.WithProject("acme/templates"),
+				gitlab.WithRef("v2"),
+				gitlab.WithToken("glpat-secret"),
+			)
+			appendTreeHandlers("&ref=v2")
+		})
+
+		It("should send the ref and the token", func() {
+			_, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, req := range server.ReceivedRequests() {
+				Expect(req.Header.Get("PRIVATE-TOKEN")).Should(Equal("glpat-secret"))
+			}
+		})
+	})
+
+	Describe("List when the server errors", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"message": "404 Project Not Found"}`),
+			)
+		})
+
+		It("should return an error", func() {
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(HavePrefix("error listing contents of acme/templates at the default branch: unable to get tree information: GET ")))
+			Expect(err).Should(MatchError(HaveSuffix(": 404 Not Found")))
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			goStatusCode int
+			goResponse   string
+		)
+
+		BeforeEach(func() {
+			goStatusCode = http.StatusOK
+			goResponse = "*.o\n*.a\n*.so\n"
+			appendTreeHandlers("")
+			server.RouteToHandler("GET", goBlobPath, ghttp.CombineHandlers(
+				ghttp.VerifyHeader(http.Header{
+					"User-Agent": expectedUserAgent,
+				}),
+				ghttp.RespondWithPtr(&goStatusCode, &goResponse),
+			))
+			server.RouteToHandler("GET", anjutaBlobPath, ghttp.RespondWith(http.StatusOK, "/.anjuta/\n"))
+		})
+
+		It("should return the contents of the files in the order of the names", func() {
+			contents, err := getter.Get(ctx, []string{"Go", "anjuta"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n*.a\n*.so\n"},
+				{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+			}))
+		})
+
+		It("should resolve patterns", func() {
+			contents, err := getter.Get(ctx, []string{"Global/*"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+			}))
+		})
+
+		It("should suggest files for names not present", func() {
+			_, err := getter.Get(ctx, []string{"Goo"})
+			Expect(err).Should(MatchError(ContainSubstring("Go.gitignore")))
+		})
+
+		When("a blob request fails", func() {
+			BeforeEach(func() {
+				goStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return the other files and an error", func() {
+				contents, err := getter.Get(ctx, []string{"Go", "anjuta"})
+				Expect(contents).Should(Equal([]getignore.NamedContents{
+					{Name: "Global/Anjuta.gitignore", Contents: "/.anjuta/\n"},
+				}))
+				Expect(err).Should(MatchError("error getting files from acme/templates at the default branch: failed to get the following files: Go.gitignore\nGo.gitignore: failed to download\n"))
+			})
+		})
+	})
+
+	Describe("GetBlob", func() {
+		var (
+			blobStatusCode int
+			blobResponse   string
+		)
+
+		BeforeEach(func() {
+			blobStatusCode = http.StatusOK
+			blobResponse = "*.o\n*.a\n*.so\n"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", goBlobPath),
+					ghttp.RespondWithPtr(&blobStatusCode, &blobResponse),
+				),
+			)
+		})
+
+		It("should return the contents of the blob", func() {
+			contents, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal("*.o\n*.a\n*.so\n"))
+		})
+
+		When("the blob request fails", func() {
+			BeforeEach(func() {
+				blobStatusCode = http.StatusInternalServerError
+			})
+
+			It("should return an error", func() {
+				_, err := getter.GetBlob(ctx, "66fd13c903cac02eb9657cd53fb227823484401d")
+				Expect(err).Should(MatchError(ContainSubstring("66fd13c903cac02eb9657cd53fb227823484401d: failed to download")))
+			})
+		})
+	})
+})
@@ -0,0 +1,9 @@
+package gitremote
+
+const (
+	Suffix = ".gitignore"
+
+	// cacheDirName is the directory, within the user's cache directory,
+	// holding the repositories fetched from remotes
+	cacheDirName = "getignore/git"
+)
@@ -0,0 +1,365 @@
+package gitremote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// Getter lists and gets files from any git remote, by fetching the tree at a
+// ref into a bare repository in a cache directory, shallowly and without
+// blobs, which are fetched only as needed.
+type Getter struct {
+	// URL is the URL of the remote, e.g., https://example.com/templates.git,
+	// ssh://git@example.com/templates.git, or file:///srv/git/templates.git
+	URL string
+	// Ref is the branch, tag, or commit to list files at, or empty for the
+	// default branch of the remote
+	Ref string
+	// CacheDir is the directory holding the repositories fetched from remotes
+	CacheDir string
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	url      string
+	ref      string
+	cacheDir string
+	resolver getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		resolver: getignore.ResolverSettings{Suffix: Suffix},
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.url == "" {
+		return Getter{}, errors.New("a git remote URL is required")
+	}
+	if err := checkRef(params.ref); err != nil {
+		return Getter{}, err
+	}
+	if params.cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return Getter{}, fmt.Errorf("unable to find a cache directory for git remotes: %w", err)
+		}
+		params.cacheDir = filepath.Join(userCacheDir, cacheDirName)
+	}
+	return Getter{
+		URL:              params.url,
+		Ref:              params.ref,
+		CacheDir:         params.cacheDir,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithURL sets the URL of the git remote for the Getter
+func WithURL(url string) GetterOption {
+	return func(p *getterParams) {
+		p.url = url
+	}
+}
+
+// WithRef sets the branch, tag, or commit for the Getter; NewGetter fails for
+// refs beginning with -
+func WithRef(ref string) GetterOption {
+	return func(p *getterParams) {
+		p.ref = ref
+	}
+}
+
+// WithCacheDir sets the directory holding the repositories fetched from
+// remotes
+func WithCacheDir(cacheDir string) GetterOption {
+	return func(p *getterParams) {
+		p.cacheDir = cacheDir
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix. Blobs are not fetched to list files, so the sizes of
+// the entries are zero.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	tree, err := g.fetchTree(ctx)
+	if err != nil {
+		return nil, g.newListError(err)
+	}
+	var entries []getignore.FileEntry
+	for _, entry := range tree {
+		if strings.HasSuffix(entry.Path, g.Suffix) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files downloaded from the given names
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	tree, err := g.fetchTree(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	pathsToSHAs := make(map[string]string, len(tree))
+	for _, entry := range tree {
+		pathsToSHAs[entry.Path] = entry.SHA
+	}
+	names, failedFiles := g.Resolver().Resolve(names, getignore.EntryPaths(tree))
+	shas := make([]string, len(names))
+	for i, name := range names {
+		shas[i] = pathsToSHAs[name]
+	}
+	blobs, err := g.readBlobs(ctx, shas)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	var namedContents []getignore.NamedContents
+	for i, name := range names {
+		contents, ok := blobs[shas[i]]
+		if !ok {
+			failedFiles = append(failedFiles, getignore.FailedFile{
+				Name:    name,
+				Message: "failed to download",
+				Err:     fmt.Errorf("blob %s is missing", shas[i]),
+			})
+			continue
+		}
+		namedContents = append(namedContents, getignore.NamedContents{Name: name, Contents: contents})
+	}
+	if failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+// GetBlob gets the contents of the blob with the SHA
+func (g Getter) GetBlob(ctx context.Context, sha string) (string, error) {
+	if err := g.initRepository(ctx); err != nil {
+		return "", g.newGetError(err)
+	}
+	contents, err := g.git(ctx, nil, "cat-file", "blob", "--end-of-options", sha)
+	if err != nil {
+		return "", g.newGetError(getignore.FailedFile{
+			Name:    sha,
+			Message: "failed to download",
+			Err:     err,
+		})
+	}
+	return string(contents), nil
+}
+
+func (g Getter) newListError(err error) error {
+	return fmt.Errorf("error listing contents of %s at %s: %w", g.URL, g.refName(), err)
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s at %s: %w", g.URL, g.refName(), err)
+}
+
+// refName names the ref for messages
+func (g Getter) refName() string {
+	if g.Ref == "" {
+		return "the default branch"
+	}
+	return g.Ref
+}
+
+// lockFileName is the name of the file in the cached repository that runs
+// lock while fetching into it
+const lockFileName = "getignore.lock"
+
+// repositoryDir returns the directory of the cached repository for the remote
+func (g Getter) repositoryDir() string {
+	return filepath.Join(g.CacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(g.URL)))[:32])
+}
+
+// initRepository creates the cached repository for the remote, if needed,
+// configured to fetch missing blobs from the remote on demand
+func (g Getter) initRepository(ctx context.Context) error {
+	dir := g.repositoryDir()
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(g.CacheDir, 0o755); err != nil {
+		return err
+	}
+	// create the repository aside and move it into place, so that runs at
+	// the same time never see it half configured
+	initDir, err := os.MkdirTemp(g.CacheDir, ".init-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(initDir)
+	if _, err := runGit(ctx, "", nil, "init", "--quiet", "--bare", initDir); err != nil {
+		return err
+	}
+	for _, setting := range [][2]string{
+		{"remote.origin.url", g.URL},
+		{"remote.origin.promisor", "true"},
+		{"remote.origin.partialclonefilter", "blob:none"},
+	} {
+		if _, err := runGit(ctx, initDir, nil, "config", "--", setting[0], setting[1]); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(initDir, dir); err != nil {
+		// another run may have moved its repository into place first
+		if _, statErr := os.Stat(filepath.Join(dir, "HEAD")); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// fetchTree fetches the commit at the ref from the remote, shallowly and
+// without blobs, and lists the files in its tree
+func (g Getter) fetchTree(ctx context.Context) ([]getignore.FileEntry, error) {
+	if err := g.initRepository(ctx); err != nil {
+		return nil, err
+	}
+	ref := g.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// hold the lock from fetching until reading FETCH_HEAD, which other
+	// runs fetching into the cached repository at once would overwrite
+	unlock, err := lockFile(filepath.Join(g.repositoryDir(), lockFileName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the cached repository: %w", err)
+	}
+	defer unlock()
+	if _, err := g.git(ctx, nil, "fetch", "--quiet", "--no-tags", "--depth=1", "--filter=blob:none", "--end-of-options", "origin", ref); err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %w", ref, err)
+	}
+	commit, err := g.git(ctx, nil, "rev-parse", "--verify", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %w", ref, err)
+	}
+	output, err := g.git(ctx, nil, "ls-tree", "-r", "-z", "--end-of-options", strings.TrimSpace(string(commit)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tree information: %w", err)
+	}
+	return parseTree(output)
+}
+
+// parseTree parses the output of git ls-tree -r -z, returning its blobs
+func parseTree(output []byte) ([]getignore.FileEntry, error) {
+	var entries []getignore.FileEntry
+	for _, record := range strings.Split(strings.TrimSuffix(string(output), "\x00"), "\x00") {
+		if record == "" {
+			continue
+		}
+		tab := strings.Index(record, "\t")
+		if tab < 0 {
+			return nil, fmt.Errorf("unable to parse tree entry %q", record)
+		}
+		fields := strings.Fields(record[:tab])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unable to parse tree entry %q", record)
+		}
+		if fields[1] == "blob" {
+			entries = append(entries, getignore.FileEntry{Path: record[tab+1:], SHA: fields[2]})
+		}
+	}
+	return entries, nil
+}
+
+// readBlobs reads the contents of the blobs with the SHAs, fetching any that
+// are missing from the remote, and returning the contents by SHA. Blobs that
+// could not be read are left out.
+func (g Getter) readBlobs(ctx context.Context, shas []string) (map[string]string, error) {
+	blobs := make(map[string]string, len(shas))
+	if len(shas) == 0 {
+		return blobs, nil
+	}
+	output, err := g.git(ctx, strings.NewReader(strings.Join(shas, "\n")+"\n"), "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for {
+		header, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return blobs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			// the object is missing
+			continue
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse object header %q", header)
+		}
+		contents := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, contents); err != nil {
+			return nil, err
+		}
+		blobs[fields[0]] = string(contents[:size])
+	}
+}
+
+// git runs git in the cached repository for the remote
+func (g Getter) git(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	return runGit(ctx, g.repositoryDir(), stdin, args...)
+}
+
+// runGit runs git with the arguments in the directory, if given, returning
+// its output, or an error including what it reported
+func runGit(ctx context.Context, dir string, stdin io.Reader, args ...string) ([]byte, error) {
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// fail rather than prompt for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", subcommand, err, message)
+		}
+		return nil, fmt.Errorf("git %s: %w", subcommand, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,199 @@
+package gitremote_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/gitremote"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx       context.Context
+		tempDir   string
+		remoteDir string
+		remoteURL string
+		cacheDir  string
+		getter    gitremote.Getter
+	)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", remoteDir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Jane Doe", "GIT_AUTHOR_EMAIL=jane@example.com",
+			"GIT_COMMITTER_NAME=Jane Doe", "GIT_COMMITTER_EMAIL=jane@example.com",
+		)
+		output, err := cmd.CombinedOutput()
+		Expect(err).ShouldNot(HaveOccurred(), string(output))
+		return string(output)
+	}
+
+	writeFile := func(name string, contents string) {
+		path := filepath.Join(remoteDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).Should(Succeed())
+		Expect(os.WriteFile(path, []byte(contents), 0o644)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		tempDir, err = os.MkdirTemp("", "getignore-gitremote-")
+		Expect(err).ShouldNot(HaveOccurred())
+		remoteDir = filepath.Join(tempDir, "remote")
+		remoteURL = "file://" + filepath.ToSlash(remoteDir)
+		cacheDir = filepath.Join(tempDir, "cache")
+		Expect(os.Mkdir(remoteDir, 0o755)).Should(Succeed())
+		git("init", "--quiet", "--initial-branch=main")
+		writeFile("README.md", "# Templates\n")
+		writeFile("Go.gitignore", "*.o\n")
+		writeFile("Global/Vim.gitignore", "*.swp\n")
+		git("add", ".")
+		git("commit", "--quiet", "-m", "Add templates")
+		git("tag", "v1")
+		writeFile("Go.gitignore", "*.o\n*.so\n")
+		git("commit", "--quiet", "-am", "Ignore shared objects")
+		getter, _ = gitremote.NewGetter(gitremote.WithURL(remoteURL), gitremote.WithCacheDir(cacheDir))
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).Should(Succeed())
+	})
+
+	Describe("NewGetter", func() {
+		It("should require a URL", func() {
+			_, err := gitremote.NewGetter()
+			Expect(err).Should(MatchError("a git remote URL is required"))
+		})
+
+		It("should reject refs that git would take as options", func() {
+			_, err := gitremote.NewGetter(gitremote.WithURL(remoteURL), gitremote.WithRef("--upload-pack=touch pwned"))
+			Expect(err).Should(MatchError(`invalid ref "--upload-pack=touch pwned": refs may not begin with -`))
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the files with the suffix on the default branch", func() {
+			files, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).Should(Equal([]string{"Global/Vim.gitignore", "Go.gitignore"}))
+		})
+
+		It("should return entries with the SHA of each file", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(ContainElement(getignore.FileEntry{
+				Path: "Go.gitignore",
+				SHA:  getignore.BlobSHA("*.o\n*.so\n"),
+			}))
+		})
+
+		It("should cache the repository", func() {
+			_, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(os.ReadDir(cacheDir)).Should(HaveLen(1))
+			_, err = getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(os.ReadDir(cacheDir)).Should(HaveLen(1))
+		})
+
+		It("should not take the ref as an option", func() {
+			marker := filepath.Join(tempDir, "pwned")
+			getter.Ref = "--upload-pack=touch " + marker + ";false"
+			_, err := getter.List(ctx)
+			Expect(err).Should(HaveOccurred())
+			Expect(marker).ShouldNot(BeAnExistingFile())
+		})
+
+		It("should list the files at each ref for runs at the same time", func() {
+			const runs = 8
+			shas := map[string]string{
+				"main": strings.TrimSpace(git("rev-parse", "main:Go.gitignore")),
+				"v1":   strings.TrimSpace(git("rev-parse", "v1:Go.gitignore")),
+			}
+			errs := make(chan error, runs)
+			for i := 0; i < runs; i++ {
+				runGetter := getter
+				runGetter.Ref = "main"
+				if i%2 == 0 {
+					runGetter.Ref = "v1"
+				}
+				go func() {
+					defer GinkgoRecover()
+					expectedSHA := shas[runGetter.Ref]
+					entries, err := runGetter.ListEntries(ctx)
+					if err == nil {
+						Expect(entries).Should(ContainElement(getignore.FileEntry{Path: "Go.gitignore", SHA: expectedSHA}))
+					}
+					errs <- err
+				}()
+			}
+			for i := 0; i < runs; i++ {
+				Expect(<-errs).ShouldNot(HaveOccurred())
+			}
+			Expect(os.ReadDir(cacheDir)).Should(HaveLen(1))
+		})
+
+		It("should not take the URL as an option", func() {
+			getter.URL = "--get-regexp"
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(ContainSubstring("unable to fetch HEAD: git fetch: ")))
+		})
+
+		It("should fail for a ref not in the remote", func() {
+			getter.Ref = "v9"
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(HavePrefix("error listing contents of " + remoteURL + " at v9: unable to fetch v9: git fetch: ")))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should return the contents of the files in the order of the names", func() {
+			contents, err := getter.Get(ctx, []string{"vim", "Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "Go.gitignore", Contents: "*.o\n*.so\n"},
+			}))
+		})
+
+		It("should return the contents of the files at the ref", func() {
+			getter.Ref = "v1"
+			contents, err := getter.Get(ctx, []string{"Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Go.gitignore", Contents: "*.o\n"},
+			}))
+		})
+
+		It("should suggest files for names not present", func() {
+			_, err := getter.Get(ctx, []string{"Goo"})
+			Expect(err).Should(MatchError(ContainSubstring("Go.gitignore")))
+		})
+	})
+
+	Describe("GetBlob", func() {
+		It("should return the contents of a fetched blob", func() {
+			_, err := getter.List(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			contents, err := getter.GetBlob(ctx, getignore.BlobSHA("*.o\n*.so\n"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal("*.o\n*.so\n"))
+		})
+
+		It("should fail for a blob not in the remote", func() {
+			_, err := getter.GetBlob(ctx, getignore.BlobSHA("*.class\n"))
+			Expect(err).Should(MatchError(ContainSubstring(getignore.BlobSHA("*.class\n") + ": failed to download")))
+		})
+
+		It("should not take the SHA as an option", func() {
+			_, err := getter.GetBlob(ctx, "--batch-all-objects")
+			Expect(err).Should(MatchError(ContainSubstring("--batch-all-objects: failed to download")))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package gitremote_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGitremote(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Git Remote Suite")
+}
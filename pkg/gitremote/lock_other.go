@@ -0,0 +1,10 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package gitremote
+
+// lockFile does not lock the file on platforms without flock, where runs at
+// the same time may still fail to fetch into the cached repository
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,59 @@
+package gitremote
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// prefixSource formats and parses sources giving the URL of a remote,
+// followed by the ref, if any
+var prefixSource = getignore.PrefixSource{Prefix: "git+", Location: "the URL of a git remote"}
+
+// FormatSource returns the source for the remote and ref, e.g.,
+// git+https://example.com/templates.git#main, for recording in banners and
+// lock files
+func FormatSource(url string, ref string) string {
+	if ref != "" {
+		url += "#" + ref
+	}
+	return prefixSource.Format(url)
+}
+
+// IsSource reports whether the source is a git remote, as given by
+// FormatSource
+func IsSource(source string) bool {
+	return prefixSource.Is(source)
+}
+
+// ParseSource parses a source given by FormatSource into the URL of the
+// remote and the ref, which is empty for the default branch
+func ParseSource(source string) (string, string, error) {
+	url, err := prefixSource.Parse(source)
+	if err != nil {
+		return "", "", err
+	}
+	ref := ""
+	if i := strings.LastIndex(url, "#"); i >= 0 {
+		url, ref = url[:i], url[i+1:]
+		if ref == "" {
+			return "", "", fmt.Errorf("invalid source %q: missing ref after #", source)
+		}
+		if err := checkRef(ref); err != nil {
+			return "", "", fmt.Errorf("invalid source %q: %w", source, err)
+		}
+	}
+	if url == "" {
+		return "", "", fmt.Errorf("invalid source %q: missing the URL of a git remote", source)
+	}
+	return url, ref, nil
+}
+
+// checkRef checks that the ref can't be taken by git as an option
+func checkRef(ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref %q: refs may not begin with -", ref)
+	}
+	return nil
+}
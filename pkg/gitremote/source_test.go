@@ -0,0 +1,41 @@
+package gitremote_test
+
+import (
+	"github.com/gotgenes/getignore/pkg/gitremote"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Source", func() {
+	It("should format a remote and ref", func() {
+		Expect(gitremote.FormatSource("https://example.com/templates.git", "main")).Should(Equal("git+https://example.com/templates.git#main"))
+	})
+
+	It("should format a remote without a ref", func() {
+		Expect(gitremote.FormatSource("file:///srv/git/templates", "")).Should(Equal("git+file:///srv/git/templates"))
+	})
+
+	It("should parse a remote and ref", func() {
+		url, ref, err := gitremote.ParseSource("git+ssh://git@example.com:2222/templates.git#v2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(url).Should(Equal("ssh://git@example.com:2222/templates.git"))
+		Expect(ref).Should(Equal("v2"))
+	})
+
+	It("should parse a remote without a ref", func() {
+		url, ref, err := gitremote.ParseSource("git+file:///srv/git/templates")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(url).Should(Equal("file:///srv/git/templates"))
+		Expect(ref).Should(BeEmpty())
+	})
+
+	It("should fail with an empty ref", func() {
+		_, _, err := gitremote.ParseSource("git+https://example.com/templates.git#")
+		Expect(err).Should(MatchError(`invalid source "git+https://example.com/templates.git#": missing ref after #`))
+	})
+
+	It("should fail with a ref that git would take as an option", func() {
+		_, _, err := gitremote.ParseSource("git+https://example.com/templates.git#--upload-pack=touch pwned")
+		Expect(err).Should(MatchError(`invalid source "git+https://example.com/templates.git#--upload-pack=touch pwned": invalid ref "--upload-pack=touch pwned": refs may not begin with -`))
+	})
+})
@@ -0,0 +1,5 @@
+package httpindex
+
+const (
+	Suffix = ".gitignore"
+)
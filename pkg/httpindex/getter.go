@@ -0,0 +1,195 @@
+package httpindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// DefaultMaxRequests is the default maximum number of concurrent requests
+var DefaultMaxRequests = getignore.DefaultMaxRequests
+
+// Getter lists and gets files published on a static web server, as listed by
+// an index.json file. Files are fetched relative to the URL of the index, and
+// checked against the SHA-256 checksums it lists.
+type Getter struct {
+	client *http.Client
+	// IndexURL is the URL of the index
+	IndexURL string
+	// Token is sent as a bearer token, if given
+	Token       string
+	MaxRequests int
+	getignore.ResolverSettings
+}
+
+// getterParams holds parameters for instantiating a Getter
+type getterParams struct {
+	client      *http.Client
+	indexURL    string
+	token       string
+	maxRequests int
+	resolver    getignore.ResolverSettings
+}
+
+func NewGetter(options ...GetterOption) (Getter, error) {
+	params := &getterParams{
+		client:      http.DefaultClient,
+		resolver:    getignore.ResolverSettings{Suffix: Suffix},
+		maxRequests: DefaultMaxRequests,
+	}
+	for _, option := range options {
+		option(params)
+	}
+	if params.indexURL == "" {
+		return Getter{}, errors.New("an index URL is required")
+	}
+	if u, err := url.Parse(params.indexURL); err != nil {
+		return Getter{}, fmt.Errorf("invalid index URL: %w", err)
+	} else if !u.IsAbs() {
+		return Getter{}, fmt.Errorf("invalid index URL %q: expected an absolute URL", params.indexURL)
+	}
+	return Getter{
+		client:           params.client,
+		IndexURL:         params.indexURL,
+		Token:            params.token,
+		MaxRequests:      params.maxRequests,
+		ResolverSettings: params.resolver,
+	}, nil
+}
+
+type GetterOption func(*getterParams)
+
+// WithClient sets the HTTP client for the Getter
+func WithClient(client *http.Client) GetterOption {
+	return func(p *getterParams) {
+		p.client = client
+	}
+}
+
+// WithIndexURL sets the URL of the index for the Getter
+func WithIndexURL(indexURL string) GetterOption {
+	return func(p *getterParams) {
+		p.indexURL = indexURL
+	}
+}
+
+// WithResolver sets how the Getter resolves names to files
+func WithResolver(opts ...getignore.ResolverOption) GetterOption {
+	return func(p *getterParams) {
+		p.resolver.Apply(opts...)
+	}
+}
+
+// WithToken sets the bearer token to authenticate with
+func WithToken(token string) GetterOption {
+	return func(p *getterParams) {
+		p.token = token
+	}
+}
+
+// WithMaxRequests sets the number of maximum concurrent HTTP requests
+func WithMaxRequests(max int) GetterOption {
+	return func(p *getterParams) {
+		p.maxRequests = max
+	}
+}
+
+// List returns an array of files filtered by the provided suffix.
+func (g Getter) List(ctx context.Context) ([]string, error) {
+	entries, err := g.ListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getignore.EntryPaths(entries), nil
+}
+
+// ListEntries returns an array of entries describing the files filtered by
+// the provided suffix. The index lists SHA-256 checksums rather than git blob
+// SHAs, so those are left empty.
+func (g Getter) ListEntries(ctx context.Context) ([]getignore.FileEntry, error) {
+	index, err := g.getIndex(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing contents of %s: %w", g.IndexURL, err)
+	}
+	var entries []getignore.FileEntry
+	for _, template := range index.Templates {
+		if strings.HasSuffix(template.Path, g.Suffix) {
+			entries = append(entries, getignore.FileEntry{Path: template.Path, Size: template.Size})
+		}
+	}
+	return entries, nil
+}
+
+// Get returns an array of contents of the files downloaded from the given
+// names, failing for those that don't match their checksums
+func (g Getter) Get(ctx context.Context, names []string) ([]getignore.NamedContents, error) {
+	index, err := g.getIndex(ctx)
+	if err != nil {
+		return nil, g.newGetError(err)
+	}
+	templates := make(map[string]Template, len(index.Templates))
+	paths := make([]string, len(index.Templates))
+	for i, template := range index.Templates {
+		templates[template.Path] = template
+		paths[i] = template.Path
+	}
+	names, unresolvedFiles := g.Resolver().Resolve(names, paths)
+	downloaded, failedFiles := getignore.DownloadFiles(ctx, names, g.MaxRequests, func(ctx context.Context, name string) (string, error) {
+		return g.getTemplate(ctx, templates[name])
+	})
+	var namedContents []getignore.NamedContents
+	for _, nc := range downloaded {
+		if err := templates[nc.Name].Verify(nc.Contents); err != nil {
+			failedFiles = append(failedFiles, getignore.FailedFile{
+				Name:    nc.Name,
+				Message: err.Error(),
+				Err:     err,
+			})
+		} else {
+			namedContents = append(namedContents, nc)
+		}
+	}
+	if failedFiles = append(unresolvedFiles, failedFiles...); failedFiles != nil {
+		err = g.newGetError(failedFiles)
+	}
+	return namedContents, err
+}
+
+func (g Getter) newGetError(err error) error {
+	return fmt.Errorf("error getting files from %s: %w", g.IndexURL, err)
+}
+
+// getIndex gets and parses the index
+func (g Getter) getIndex(ctx context.Context) (Index, error) {
+	body, err := g.get(ctx, g.IndexURL)
+	if err != nil {
+		return Index{}, fmt.Errorf("unable to get the index: %w", err)
+	}
+	return ParseIndex(body)
+}
+
+// getTemplate gets the contents of the template, relative to the URL of the
+// index
+func (g Getter) getTemplate(ctx context.Context, template Template) (string, error) {
+	indexURL, err := url.Parse(g.IndexURL)
+	if err != nil {
+		return "", err
+	}
+	body, err := g.get(ctx, indexURL.ResolveReference(&url.URL{Path: template.Path}).String())
+	return string(body), err
+}
+
+// get requests the URL, returning the body of the response
+func (g Getter) get(ctx context.Context, u string) ([]byte, error) {
+	body, _, err := getignore.HTTPGet(ctx, g.client, u, func(req *http.Request) {
+		if g.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+g.Token)
+		}
+	})
+	return body, err
+}
@@ -0,0 +1,117 @@
+package httpindex_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+	"github.com/gotgenes/getignore/pkg/httpindex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Getter", func() {
+	var (
+		ctx               context.Context
+		server            *ghttp.Server
+		getter            httpindex.Getter
+		expectedUserAgent = []string{fmt.Sprintf("getignore/%s", getignore.Version)}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+		getter, _ = httpindex.NewGetter(
+			httpindex.WithIndexURL(server.URL()+"/gitignore/index.json"),
+			httpindex.WithToken("artifact-token"),
+		)
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/gitignore/index.json"),
+			ghttp.VerifyHeader(http.Header{
+				"User-Agent":    expectedUserAgent,
+				"Authorization": []string{"Bearer artifact-token"},
+			}),
+			ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+  "templates": [
+    {"path": "README.md", "sha256": "%s", "size": 12},
+    {"path": "Go.gitignore", "sha256": "%s", "size": 12},
+    {"path": "Global/Vim.gitignore", "sha256": "%s", "size": 6}
+  ]
+}`, httpindex.SHA256("# Templates\n"), httpindex.SHA256("*.o\n*.a\n*.so\n"), httpindex.SHA256("*.swp\n"))),
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGetter", func() {
+		It("should require an index URL", func() {
+			_, err := httpindex.NewGetter()
+			Expect(err).Should(MatchError("an index URL is required"))
+		})
+
+		It("should require an absolute index URL", func() {
+			_, err := httpindex.NewGetter(httpindex.WithIndexURL("templates/index.json"))
+			Expect(err).Should(MatchError(`invalid index URL "templates/index.json": expected an absolute URL`))
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the files with the suffix", func() {
+			entries, err := getter.ListEntries(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).Should(Equal([]getignore.FileEntry{
+				{Path: "Go.gitignore", Size: 12},
+				{Path: "Global/Vim.gitignore", Size: 6},
+			}))
+		})
+
+		It("should return an error for an invalid index", func() {
+			server.SetHandler(0, ghttp.RespondWith(http.StatusOK, `{"templates": [{"path": "Go.gitignore"}]}`))
+			_, err := getter.List(ctx)
+			Expect(err).Should(MatchError(fmt.Sprintf("error listing contents of %s/gitignore/index.json: invalid index: Go.gitignore has no valid SHA-256 checksum", server.URL())))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get the files relative to the index in the order of the names", func() {
+			server.RouteToHandler("GET", "/gitignore/Go.gitignore", ghttp.CombineHandlers(
+				ghttp.VerifyHeader(http.Header{
+					"Authorization": []string{"Bearer artifact-token"},
+				}),
+				ghttp.RespondWith(http.StatusOK, "*.o\n*.a\n*.so\n"),
+			))
+			server.RouteToHandler("GET", "/gitignore/Global/Vim.gitignore", ghttp.RespondWith(http.StatusOK, "*.swp\n"))
+			contents, err := getter.Get(ctx, []string{"vim", "Go"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(contents).Should(Equal([]getignore.NamedContents{
+				{Name: "Global/Vim.gitignore", Contents: "*.swp\n"},
+				{Name: "Go.gitignore", Contents: "*.o\n*.a\n*.so\n"},
+			}))
+		})
+
+		It("should report files not matching their checksums", func() {
+			server.RouteToHandler("GET", "/gitignore/Go.gitignore", ghttp.RespondWith(http.StatusOK, "*.o\n"))
+			contents, err := getter.Get(ctx, []string{"Go"})
+			Expect(err).Should(MatchError(fmt.Sprintf("error getting files from %s/gitignore/index.json: failed to get the following files: Go.gitignore\nGo.gitignore: checksum mismatch: expected SHA-256 %s, got %s\n", server.URL(), httpindex.SHA256("*.o\n*.a\n*.so\n"), httpindex.SHA256("*.o\n"))))
+			Expect(contents).Should(BeEmpty())
+		})
+
+		It("should report files that fail to download", func() {
+			server.RouteToHandler("GET", "/gitignore/Go.gitignore", ghttp.RespondWith(http.StatusNotFound, ""))
+			_, err := getter.Get(ctx, []string{"Go"})
+			var failedFiles getignore.FailedFiles
+			Expect(errors.As(err, &failedFiles)).Should(BeTrue())
+			Expect(failedFiles[0].Err).Should(MatchError(fmt.Sprintf("GET %s/gitignore/Go.gitignore: 404 Not Found", server.URL())))
+		})
+
+		It("should suggest files for names not present", func() {
+			_, err := getter.Get(ctx, []string{"Goo"})
+			Expect(err).Should(MatchError(ContainSubstring("Go.gitignore")))
+		})
+	})
+})
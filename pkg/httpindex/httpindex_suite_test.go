@@ -0,0 +1,13 @@
+package httpindex_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHttpindex(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTP Index Suite")
+}
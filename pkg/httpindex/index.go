@@ -0,0 +1,62 @@
+package httpindex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Index lists the templates published alongside it, as an index.json file on
+// a static web server
+type Index struct {
+	Templates []Template `json:"templates"`
+}
+
+// Template describes a template listed by an Index
+type Template struct {
+	// Path is the path of the template, relative to the URL of the index
+	Path string `json:"path"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the contents of the
+	// template
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size,omitempty"`
+}
+
+// ParseIndex parses an index, checking that each template has a relative path
+// within the directory of the index, and a SHA-256 checksum
+func ParseIndex(data []byte) (Index, error) {
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return Index{}, fmt.Errorf("invalid index: %w", err)
+	}
+	for i, template := range index.Templates {
+		if template.Path == "" {
+			return Index{}, fmt.Errorf("invalid index: template %d has no path", i+1)
+		}
+		if cleaned := path.Clean(template.Path); cleaned != template.Path || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return Index{}, fmt.Errorf("invalid index: %s is not a relative path within the directory of the index", template.Path)
+		}
+		if sum, err := hex.DecodeString(template.SHA256); err != nil || len(sum) != sha256.Size {
+			return Index{}, fmt.Errorf("invalid index: %s has no valid SHA-256 checksum", template.Path)
+		}
+	}
+	return index, nil
+}
+
+// SHA256 returns the hex-encoded SHA-256 checksum of the contents, as listed
+// by an Index
+func SHA256(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks the contents against the checksum of the template
+func (t Template) Verify(contents string) error {
+	if sum := SHA256(contents); !strings.EqualFold(sum, t.SHA256) {
+		return fmt.Errorf("checksum mismatch: expected SHA-256 %s, got %s", t.SHA256, sum)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package httpindex_test
+
+import (
+	"github.com/gotgenes/getignore/pkg/httpindex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Index", func() {
+	goSHA256 := httpindex.SHA256("*.o\n")
+
+	Describe("ParseIndex", func() {
+		It("should parse the templates", func() {
+			index, err := httpindex.ParseIndex([]byte(`{"templates": [{"path": "Go.gitignore", "sha256": "` + goSHA256 + `", "size": 4}]}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(index.Templates).Should(Equal([]httpindex.Template{
+				{Path: "Go.gitignore", SHA256: goSHA256, Size: 4},
+			}))
+		})
+
+		It("should fail for paths outside the directory of the index", func() {
+			_, err := httpindex.ParseIndex([]byte(`{"templates": [{"path": "../Go.gitignore", "sha256": "` + goSHA256 + `"}]}`))
+			Expect(err).Should(MatchError("invalid index: ../Go.gitignore is not a relative path within the directory of the index"))
+		})
+
+		It("should fail for absolute paths", func() {
+			_, err := httpindex.ParseIndex([]byte(`{"templates": [{"path": "/Go.gitignore", "sha256": "` + goSHA256 + `"}]}`))
+			Expect(err).Should(MatchError("invalid index: /Go.gitignore is not a relative path within the directory of the index"))
+		})
+
+		It("should fail for templates without a checksum", func() {
+			_, err := httpindex.ParseIndex([]byte(`{"templates": [{"path": "Go.gitignore"}]}`))
+			Expect(err).Should(MatchError("invalid index: Go.gitignore has no valid SHA-256 checksum"))
+		})
+
+		It("should fail for templates without a path", func() {
+			_, err := httpindex.ParseIndex([]byte(`{"templates": [{"sha256": "` + goSHA256 + `"}]}`))
+			Expect(err).Should(MatchError("invalid index: template 1 has no path"))
+		})
+
+		It("should fail for invalid JSON", func() {
+			_, err := httpindex.ParseIndex([]byte(`{"templates": `))
+			Expect(err).Should(MatchError(HavePrefix("invalid index: ")))
+		})
+	})
+
+	Describe("Verify", func() {
+		It("should accept contents matching the checksum", func() {
+			Expect(httpindex.Template{Path: "Go.gitignore", SHA256: goSHA256}.Verify("*.o\n")).Should(Succeed())
+		})
+
+		It("should reject contents not matching the checksum", func() {
+			err := httpindex.Template{Path: "Go.gitignore", SHA256: goSHA256}.Verify("*.a\n")
+			Expect(err).Should(MatchError("checksum mismatch: expected SHA-256 " + goSHA256 + ", got " + httpindex.SHA256("*.a\n")))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package httpindex
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gotgenes/getignore/pkg/getignore"
+)
+
+// prefixSource formats and parses sources giving the URL of an index
+var prefixSource = getignore.PrefixSource{Prefix: "index+", Location: "the URL of an index"}
+
+// FormatSource returns the source for the index at the URL, e.g.,
+// index+https://example.com/templates/index.json, for recording in banners
+// and lock files
+func FormatSource(indexURL string) string {
+	return prefixSource.Format(indexURL)
+}
+
+// IsSource reports whether the source is an index, as given by FormatSource
+func IsSource(source string) bool {
+	return prefixSource.Is(source)
+}
+
+// ParseSource parses a source given by FormatSource into the URL of the index
+func ParseSource(source string) (string, error) {
+	return prefixSource.Parse(source)
+}
+
+// SameOrigin reports whether the URLs have the same scheme, host, and port,
+// so that credentials for one may be sent to the other
+func SameOrigin(url1 string, url2 string) bool {
+	u1, err := url.Parse(url1)
+	if err != nil {
+		return false
+	}
+	u2, err := url.Parse(url2)
+	if err != nil {
+		return false
+	}
+	return u1.Host != "" && strings.EqualFold(u1.Scheme, u2.Scheme) && strings.EqualFold(u1.Host, u2.Host)
+}
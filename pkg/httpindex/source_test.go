@@ -0,0 +1,20 @@
+package httpindex_test
+
+import (
+	"github.com/gotgenes/getignore/pkg/httpindex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SameOrigin", func() {
+	It("should match URLs on the same host", func() {
+		Expect(httpindex.SameOrigin("https://artifacts.example.com/gitignore/index.json", "https://Artifacts.example.com/other/index.json")).Should(BeTrue())
+	})
+
+	It("should not match URLs on other hosts, ports, or schemes", func() {
+		Expect(httpindex.SameOrigin("https://attacker.example.net/index.json", "https://artifacts.example.com/gitignore/index.json")).Should(BeFalse())
+		Expect(httpindex.SameOrigin("https://artifacts.example.com:8443/index.json", "https://artifacts.example.com/gitignore/index.json")).Should(BeFalse())
+		Expect(httpindex.SameOrigin("http://artifacts.example.com/index.json", "https://artifacts.example.com/gitignore/index.json")).Should(BeFalse())
+		Expect(httpindex.SameOrigin("https://artifacts.example.com/index.json", "")).Should(BeFalse())
+	})
+})